@@ -0,0 +1,30 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/reconquest/hierr-go"
+)
+
+// askConfirmation prints question to stderr and reads answer from stdin.
+// Only "y" and "yes" answers are considered positive, and closed stdin is
+// considered negative answer.
+func askConfirmation(question string) (bool, error) {
+	fmt.Fprintf(os.Stderr, "%s [y/N] ", question)
+
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, hierr.Errorf(err, "unable to read answer from stdin")
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	}
+
+	return false, nil
+}
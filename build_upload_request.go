@@ -0,0 +1,195 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/Smartling/api-sdk-go"
+	"github.com/reconquest/hierr-go"
+)
+
+func buildUploadRequest(
+	config Config,
+	args map[string]interface{},
+	base string,
+	file string,
+) (*smartling.FileUploadRequest, error) {
+	var (
+		uri, useURI   = args["<uri>"].(string)
+		branch, _     = args["--branch"].(string)
+		locales, _    = args["--locale"].([]string)
+		authorize, _  = args["--authorize"].(bool)
+		fileType, _   = args["--type"].(string)
+		directives, _ = args["--directive"].([]string)
+		uriFormat, _  = args["--uri-format"].(string)
+		namespace, _  = args["--namespace"].(string)
+	)
+
+	name, err := filepath.Abs(file)
+	if err != nil {
+		return nil, NewError(
+			hierr.Errorf(
+				err,
+				`unable to resolve absolute path to file: %q`,
+				file,
+			),
+
+			`Check, that file exists and you have proper permissions `+
+				`to access it.`,
+		)
+	}
+
+	if !filepath.HasPrefix(name, base) {
+		return nil, NewError(
+			errors.New(
+				`you are trying to push file outside project directory`,
+			),
+
+			`Check file path and path to configuration file and try again.`,
+		)
+	}
+
+	name, err = filepath.Rel(base, name)
+	if err != nil {
+		return nil, NewError(
+			hierr.Errorf(
+				err,
+				`unable to resolve relative path to file: %q`,
+				file,
+			),
+
+			`Check, that file exists and you have proper permissions `+
+				`to access it.`,
+		)
+	}
+
+	if !useURI {
+		uri = name
+	}
+
+	fileConfig, err := config.GetFileConfig(file)
+	if err != nil {
+		return nil, NewError(
+			hierr.Errorf(
+				err,
+				`unable to retrieve file specific configuration`,
+			),
+
+			``,
+		)
+	}
+
+	contents, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, NewError(
+			hierr.Errorf(
+				err,
+				`unable to read file contents "%s"`,
+				file,
+			),
+
+			`Check that file exists and readable by current user.`,
+		)
+	}
+
+	request := &smartling.FileUploadRequest{
+		File:               contents,
+		Authorize:          authorize,
+		LocalesToAuthorize: locales,
+	}
+
+	request.FileURI = branch + uri
+
+	if uriFormat != "" {
+		format, err := compileFormat(uriFormat)
+		if err != nil {
+			return nil, err
+		}
+
+		request.FileURI, err = format.Execute(map[string]interface{}{
+			"FileURI": uri,
+			"Branch":  branch,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if fileConfig.Push.Type == "" {
+		if fileType == "" {
+			request.FileType = smartling.GetFileTypeByExtension(
+				filepath.Ext(file),
+			)
+
+			if request.FileType == smartling.FileTypeUnknown {
+				request.FileType = detectFileType(contents)
+
+				if request.FileType != smartling.FileTypeUnknown {
+					logger.Infof(
+						"detected file type of %s from contents: %s",
+						file,
+						request.FileType,
+					)
+				}
+			}
+
+			if request.FileType == smartling.FileTypeUnknown {
+				return nil, NewError(
+					fmt.Errorf(
+						"unable to deduce file type from extension %q "+
+							"or contents",
+						filepath.Ext(file),
+					),
+
+					`You need to specify file type via --type option.`,
+				)
+			}
+		} else {
+			request.FileType = smartling.FileType(fileType)
+		}
+	} else {
+		request.FileType = smartling.FileType(fileConfig.Push.Type)
+	}
+
+	// directives from config file can be shared by several files, which
+	// are pushed concurrently, so they are copied before --directive
+	// values are applied
+	if len(fileConfig.Push.Directives) > 0 || len(directives) > 0 {
+		request.Smartling.Directives = map[string]string{}
+	}
+
+	for name, value := range fileConfig.Push.Directives {
+		request.Smartling.Directives[name] = value
+	}
+
+	for _, directive := range directives {
+		spec := strings.SplitN(directive, "=", 2)
+		if len(spec) != 2 {
+			return nil, NewError(
+				fmt.Errorf(
+					"invalid directive specification: %q",
+					directive,
+				),
+
+				`Should be in the form of <name>=<value>.`,
+			)
+		}
+
+		request.Smartling.Directives[spec[0]] = spec[1]
+	}
+
+	if namespace == "" {
+		namespace = fileConfig.Push.Namespace
+	}
+
+	if namespace != "" {
+		request.Smartling.Namespace = namespace
+
+		logger.Infof("%s will be uploaded to namespace %q", file, namespace)
+	}
+
+	return request, nil
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Smartling/api-sdk-go"
+)
+
+// checkFilesToPush uploads files as temporary ones to count strings which
+// are not known to project yet and fails if none of files has new strings.
+// Files in project are not changed.
+func checkFilesToPush(
+	client *smartling.Client,
+	config Config,
+	args map[string]interface{},
+	base string,
+	files []string,
+) error {
+	var (
+		branch, _ = args["--branch"].(string)
+	)
+
+	total := 0
+
+	for _, file := range files {
+		request, err := buildUploadRequest(config, args, base, file)
+		if err != nil {
+			return err
+		}
+
+		uri := request.FileURI
+
+		added, _, err := diffFile(client, config, request)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf(
+			"%s (%s) %d new strings [check only]\n",
+			strings.TrimPrefix(uri, branch),
+			request.FileType,
+			added,
+		)
+
+		total += added
+	}
+
+	if total == 0 {
+		return noNewStringsError{Files: len(files)}
+	}
+
+	return nil
+}
+
+// noNewStringsError is reported by --check-only when there is nothing new to
+// push, so it can be told apart from failures by exit code.
+type noNewStringsError struct {
+	Files int
+}
+
+func (err noNewStringsError) Error() string {
+	return NewError(
+		fmt.Errorf(`none of %d files contain new strings`, err.Files),
+
+		`Add new strings to source files or make sure that correct `+
+			`files are pushed.`,
+	).Error()
+}
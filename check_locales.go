@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/Smartling/api-sdk-go"
+	"github.com/reconquest/hierr-go"
+)
+
+// checkLocales checks, that specified locales are project target locales.
+// Locales from --locale-list-file are used instead of API if specified.
+func checkLocales(
+	client *smartling.Client,
+	config Config,
+	locales []string,
+) error {
+	project := config.ProjectID
+
+	known := config.locales
+
+	if known == nil {
+		details, err := client.GetProjectDetails(project)
+		if err != nil {
+			if _, ok := err.(smartling.NotFoundError); ok {
+				return ProjectNotFoundError{}
+			}
+
+			return hierr.Errorf(
+				err,
+				`unable to get project "%s" details`,
+				project,
+			)
+		}
+
+		for _, locale := range details.TargetLocales {
+			known = append(known, locale.LocaleID)
+		}
+	}
+
+	for _, locale := range locales {
+		if !hasLocaleInList(locale, known) {
+			return NewError(
+				fmt.Errorf(
+					`locale "%s" is not found in project "%s"`,
+					locale,
+					project,
+				),
+
+				`Check locale ID and list of project target locales using `+
+					`"projects locales" command.`,
+			)
+		}
+	}
+
+	return nil
+}
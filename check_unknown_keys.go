@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// checkUnknownKeys walks through YAML document and returns error if any of
+// mapping keys do not correspond to fields of target type, so typos in
+// config file are not silently ignored.
+func checkUnknownKeys(
+	document interface{},
+	target reflect.Type,
+	path string,
+) error {
+	for target.Kind() == reflect.Ptr {
+		target = target.Elem()
+	}
+
+	mapping, ok := document.(map[interface{}]interface{})
+	if !ok {
+		return nil
+	}
+
+	switch target.Kind() {
+	case reflect.Map:
+		for key, value := range mapping {
+			err := checkUnknownKeys(
+				value,
+				target.Elem(),
+				joinConfigKey(path, fmt.Sprint(key)),
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+	case reflect.Struct:
+		fields := map[string]reflect.Type{}
+
+		for i := 0; i < target.NumField(); i++ {
+			field := target.Field(i)
+
+			name := strings.Split(field.Tag.Get("yaml"), ",")[0]
+			if name == "" || name == "-" {
+				continue
+			}
+
+			fields[name] = field.Type
+		}
+
+		for key, value := range mapping {
+			name := fmt.Sprint(key)
+
+			field, ok := fields[name]
+			if !ok {
+				return fmt.Errorf(
+					`unknown config key "%s"`,
+					joinConfigKey(path, name),
+				)
+			}
+
+			err := checkUnknownKeys(value, field, joinConfigKey(path, name))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func joinConfigKey(path string, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/reconquest/hierr-go"
+)
+
+// checksumMismatchError is returned when file contents do not match
+// checksum stored alongside the file.
+type checksumMismatchError struct {
+	Paths []string
+}
+
+func (err checksumMismatchError) Error() string {
+	return fmt.Sprintf(
+		"checksum verification failed for: %s",
+		strings.Join(err.Paths, ", "),
+	)
+}
+
+func getChecksumPath(path string) string {
+	return path + ".sha256"
+}
+
+func computeChecksum(data []byte) string {
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
+
+// readChecksum returns checksum stored alongside specified file or empty
+// string if there is no checksum file.
+func readChecksum(path string) (string, error) {
+	data, err := ioutil.ReadFile(getChecksumPath(path))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+
+		return "", hierr.Errorf(
+			err,
+			`unable to read checksum file "%s"`,
+			getChecksumPath(path),
+		)
+	}
+
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return "", nil
+	}
+
+	return fields[0], nil
+}
+
+// writeChecksum stores checksum in the same format as sha256sum does, so
+// files can be checked with "sha256sum -c" as well.
+func writeChecksum(path string, checksum string) error {
+	err := ioutil.WriteFile(
+		getChecksumPath(path),
+		[]byte(fmt.Sprintf("%s  %s\n", checksum, filepath.Base(path))),
+		0644,
+	)
+	if err != nil {
+		return hierr.Errorf(
+			err,
+			`unable to write checksum file "%s"`,
+			getChecksumPath(path),
+		)
+	}
+
+	return nil
+}
+
+// verifyChecksum checks file against checksum stored alongside it or in
+// manifest, if it's given.
+func verifyChecksum(path string, manifest *FileHashes) (bool, error) {
+	var (
+		expected string
+		err      error
+	)
+
+	if manifest != nil {
+		expected = manifest.Get(path)
+	} else {
+		expected, err = readChecksum(path)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if expected == "" {
+		return false, nil
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+
+		return false, hierr.Errorf(
+			err,
+			`unable to read file "%s"`,
+			path,
+		)
+	}
+
+	return computeChecksum(data) == expected, nil
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"net/http"
+)
+
+// defaultConcurrency is maximum number of in-flight API requests, which is
+// used if it's not set in config file or via --concurrency option.
+const defaultConcurrency = 10
+
+// ConcurrencyTransport limits number of API requests, which are executed at
+// the same time, regardless of how many goroutines are making them, so
+// large projects do not hit API rate limits.
+type ConcurrencyTransport struct {
+	http.RoundTripper
+
+	semaphore chan struct{}
+}
+
+func NewConcurrencyTransport(
+	transport http.RoundTripper,
+	limit int,
+) *ConcurrencyTransport {
+	return &ConcurrencyTransport{
+		RoundTripper: transport,
+
+		semaphore: make(chan struct{}, limit),
+	}
+}
+
+func (transport *ConcurrencyTransport) RoundTrip(
+	request *http.Request,
+) (*http.Response, error) {
+	select {
+	case transport.semaphore <- struct{}{}:
+		// acquired
+
+	case <-request.Context().Done():
+		return nil, request.Context().Err()
+	}
+
+	defer func() {
+		<-transport.semaphore
+	}()
+
+	return transport.RoundTripper.RoundTrip(request)
+}
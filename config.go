@@ -2,23 +2,25 @@ package main
 
 import (
 	"os"
+	"time"
 
 	"github.com/gobwas/glob"
 	"github.com/imdario/mergo"
 	"github.com/kovetskiy/ko"
 	"github.com/reconquest/hierr-go"
-	"gopkg.in/yaml.v2"
 )
 
 type FileConfig struct {
 	Pull struct {
-		Format string `yaml:"format,omitempty"`
-	} `yaml:"pull,omitempty"`
+		Format         string `yaml:"format,omitempty" json:"format,omitempty"`
+		OverwriteEmpty bool   `yaml:"overwrite_empty,omitempty" json:"overwrite_empty,omitempty"`
+	} `yaml:"pull,omitempty" json:"pull"`
 
 	Push struct {
-		Type       string            `yaml:"type,omitempty"`
-		Directives map[string]string `yaml:"directives,omitempty,flow"`
-	} `yaml:"push,omitempty"`
+		Type       string            `yaml:"type,omitempty" json:"type,omitempty"`
+		Namespace  string            `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+		Directives map[string]string `yaml:"directives,omitempty,flow" json:"directives,omitempty"`
+	} `yaml:"push,omitempty" json:"push"`
 }
 
 type Config struct {
@@ -28,11 +30,30 @@ type Config struct {
 	ProjectID string `yaml:"project_id,omitempty"`
 	Threads   int    `yaml:"threads"`
 
+	ParallelDownloads int `yaml:"parallel_downloads"`
+	ParallelUploads   int `yaml:"parallel_uploads"`
+	Concurrency       int `yaml:"concurrency"`
+
+	// pointers are used to tell zero values, which are meaningful for
+	// these options, from missing ones; they are never nil after config
+	// is loaded
+	RetryCount *int           `yaml:"retry_count"`
+	RetryDelay *time.Duration `yaml:"retry_delay"`
+	Timeout    *time.Duration `yaml:"timeout"`
+
 	Files map[string]FileConfig `yaml:"files"`
 
+	LocaleMap map[string]string `yaml:"locale_map,omitempty"`
+
 	Proxy string `yaml:"proxy,omitempty"`
 
 	path string
+
+	// ignore contains glob masks read from ignore file next to config file
+	ignore []string
+
+	// locales contains target locales read from --locale-list-file
+	locales []string
 }
 
 func NewConfig(path string) (Config, error) {
@@ -40,7 +61,7 @@ func NewConfig(path string) (Config, error) {
 		path: path,
 	}
 
-	err := ko.Load(path, &config, yaml.Unmarshal)
+	err := ko.Load(path, &config, unmarshalConfig)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return config, nil
@@ -85,6 +106,16 @@ func (config *Config) GetFileConfig(path string) (FileConfig, error) {
 		return defaults, nil
 	}
 
+	directives := map[string]string{}
+
+	for name, value := range defaults.Push.Directives {
+		directives[name] = value
+	}
+
+	for name, value := range match.Push.Directives {
+		directives[name] = value
+	}
+
 	err := mergo.Merge(&match, defaults)
 	if err != nil {
 		return FileConfig{}, NewError(
@@ -93,5 +124,21 @@ func (config *Config) GetFileConfig(path string) (FileConfig, error) {
 		)
 	}
 
+	// directives are merged one by one, so file-specific section can
+	// override only some of default directives
+	if len(directives) > 0 {
+		match.Push.Directives = directives
+	}
+
 	return match, nil
 }
+
+// MapLocale returns locale name which should be used in local file paths
+// for specified Smartling locale.
+func (config *Config) MapLocale(locale string) string {
+	if mapped, ok := config.LocaleMap[locale]; ok {
+		return mapped
+	}
+
+	return locale
+}
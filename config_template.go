@@ -12,6 +12,17 @@ var (
 # Config file is optional and all configuration options can be set from command
 # line interface.
 
+# Config file can be written in JSON as well. Unknown keys are reported as
+# errors to catch typos in key names.
+
+# Any string value can reference environment variable as ${NAME}, so
+# credentials do not need to be stored in the file itself, e.g.:
+#
+#   secret: "${SMARTLING_TOKEN}"
+#
+# Command will fail if referenced variable is not set. Additional variables
+# can be loaded from file via --env-file option.
+
 # (required) Smartling API V2.0 User Identifier used for authentication.
 #
 # Must be set either in config file or be passed via command line arguments.
@@ -43,6 +54,24 @@ project_id:
 #proxy:
 #    "PROXY_URL"
 
+# (optional) Maximum number of API requests executed at once, which keeps
+# large projects within Smartling API rate limits. Default is 10.
+#concurrency: 10
+
+# (optional) How many times request should be retried if it fails because of
+# network error, server error or Smartling API rate limits and initial delay
+# between retries. Delay is doubled after every attempt.
+#retry_count: 3
+#retry_delay: 1s
+
+# (optional) Maximum time for single API request, including all its retries.
+#timeout: 30s
+
+# (optional) Locale names to use in local file paths instead of Smartling
+# locale IDs. Locales which are not listed are used as is.
+#locale_map:
+#    zh-TW: "zh_TW"
+
 # (optional) Additional file-specific settings for push and pull commands.
 files:
     # (optional) Special default section will apply configuration to all file
@@ -54,9 +83,26 @@ files:
             #
             # If not set, then default format will be used or format,
             # that is set via command line options.
-            format: "{{name .FileURI}}{{with .Locale}}_{{.}}{{end}}{{ext .FileURI}}"
+            format: "{% .File.Format %}"
 
+            # (optional) Overwrite only local files which are empty or
+            # contain no strings, same as --overwrite-empty option.
+            #overwrite_empty: true
 
+        # (optional) Defines push-specific options.
+        #push:
+            # (optional) Default API directives, which are used for all
+            # files. File-specific directives are merged with these ones
+            # and take precedence.
+            #directives:
+            #    file_charset: "utf-8"
+
+{% if .File.Mask %}
+    # Files which will be pushed by default and their specific settings.
+    "{% .File.Mask %}":
+        push:
+            type: "{% .File.Type %}"
+{% else %}
     # (optional) Specific file settings which uses same pattern rules as CLI
     # tool:
     # > *  - matches everything except /.
@@ -69,11 +115,18 @@ files:
         # (optional) Defines push-specific options.
         push:
             # (optional) Overrides automatically detected file type.
-            type: "java_properties"
+            type: "javaProperties"
+
+            # (optional) Smartling namespace to upload strings into. Can be
+            # overridden by --namespace option.
+            #namespace: "java"
 
             # (optional) Sets specific API directives, which are used only
             # for push command. Refer to Smartling API documentation for
             # list of that directives.
+            #
+            # Parser settings for specific file types, like placeholder
+            # format or whitespace handling, are set there as well.
             directives:
                 namespace: "java"
                 file_charset: "utf-8"
@@ -82,6 +135,7 @@ files:
 
         pull:
             format: "{{name .FileURI}}{{with .Locale}}_{{.}}{{end}}{{ext .FileURI}}"
+{% end %}
 
 # vim: ft=yaml
 `)))
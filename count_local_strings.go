@@ -0,0 +1,73 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/Smartling/api-sdk-go"
+)
+
+// countLocalStrings returns number of non-empty strings in file contents
+// without uploading it. False is returned for file types, which can't be
+// parsed locally.
+func countLocalStrings(
+	fileType smartling.FileType,
+	contents []byte,
+) (int, bool, error) {
+	switch fileType {
+	case smartling.FileTypeJSON, smartling.FileTypeYAML:
+		entries, err := flattenStrings(contents)
+		if err != nil {
+			return 0, true, err
+		}
+
+		count := 0
+
+		for _, entry := range entries {
+			if entry.Value != "" {
+				count++
+			}
+		}
+
+		return count, true, nil
+
+	case smartling.FileTypePlaintext:
+		return countLines(contents, func(line string) bool {
+			return line != ""
+		}), true, nil
+
+	case smartling.FileTypeJavaProperties:
+		continued := false
+
+		return countLines(contents, func(line string) bool {
+			// value can span several lines, ending with backslash
+			previous := continued
+			continued = strings.HasSuffix(line, `\`)
+
+			switch {
+			case previous, line == "":
+				return false
+
+			case strings.HasPrefix(line, "#"), strings.HasPrefix(line, "!"):
+				continued = false
+
+				return false
+			}
+
+			return true
+		}), true, nil
+	}
+
+	return 0, false, nil
+}
+
+func countLines(contents []byte, match func(line string) bool) int {
+	count := 0
+
+	for _, line := range strings.Split(string(contents), "\n") {
+		if match(strings.TrimSpace(line)) {
+			count++
+		}
+	}
+
+	return count
+}
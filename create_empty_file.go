@@ -0,0 +1,55 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/reconquest/hierr-go"
+)
+
+// createEmptyFile writes empty file in place of missing translation. With
+// --atomic, file is registered in pending writes instead, so it's created
+// only if whole pull succeeds.
+func createEmptyFile(path string, pending *PendingWrites) error {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return hierr.Errorf(
+			err,
+			`unable to create dirs hierarchy "%s" for empty file`,
+			path,
+		)
+	}
+
+	if pending != nil {
+		temp, _, err := writeTempFile(path, bytes.NewReader(nil))
+		if err != nil {
+			return hierr.Errorf(err, `unable to create empty file "%s"`, path)
+		}
+
+		pending.Add(temp, func() error {
+			err := os.Rename(temp, path)
+			if err != nil {
+				os.Remove(temp)
+
+				return hierr.Errorf(
+					err,
+					`unable to create empty file "%s"`,
+					path,
+				)
+			}
+
+			return nil
+		})
+
+		return nil
+	}
+
+	err = ioutil.WriteFile(path, nil, 0644)
+	if err != nil {
+		return hierr.Errorf(err, `unable to create empty file "%s"`, path)
+	}
+
+	return nil
+}
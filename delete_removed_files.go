@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Smartling/api-sdk-go"
+	"github.com/reconquest/hierr-go"
+)
+
+// deleteRemovedFiles deletes files from project, which URIs start with
+// current branch prefix, but which have no counterpart among local files.
+// Files to delete are listed and deletion should be confirmed unless
+// --force is given.
+func deleteRemovedFiles(
+	client *smartling.Client,
+	config Config,
+	args map[string]interface{},
+	options PushOptions,
+	base string,
+	files []string,
+) error {
+	var (
+		project   = config.ProjectID
+		branch, _ = args["--branch"].(string)
+		dryRun, _ = args["--dry-run"].(bool)
+		force, _  = args["--force"].(bool)
+		report    = options.Report
+	)
+
+	local := map[string]bool{}
+
+	for _, file := range files {
+		request, err := buildUploadRequest(config, args, base, file)
+		if err != nil {
+			return err
+		}
+
+		local[strings.TrimPrefix(request.FileURI, "/")] = true
+	}
+
+	remote, err := globFilesRemote(client, project, "")
+	if err != nil {
+		return err
+	}
+
+	var removed []smartling.File
+
+	for _, file := range remote {
+		uri := strings.TrimPrefix(file.FileURI, "/")
+
+		if !strings.HasPrefix(uri, branch) || local[uri] {
+			continue
+		}
+
+		removed = append(removed, file)
+	}
+
+	if len(removed) == 0 {
+		logger.Infof("no removed files found in project")
+
+		return nil
+	}
+
+	if dryRun {
+		for _, file := range removed {
+			fmt.Printf("%s will be deleted [dry run]\n", file.FileURI)
+		}
+
+		return nil
+	}
+
+	if !force {
+		fmt.Fprintln(os.Stderr, "following files are removed locally:")
+
+		for _, file := range removed {
+			fmt.Fprintf(os.Stderr, "  %s\n", file.FileURI)
+		}
+
+		confirmed, err := askConfirmation(
+			fmt.Sprintf("delete %d files from project?", len(removed)),
+		)
+		if err != nil {
+			return err
+		}
+
+		if !confirmed {
+			return NewError(
+				fmt.Errorf(
+					`deletion of %d removed files is not confirmed`,
+					len(removed),
+				),
+
+				`Answer "y" to delete listed files or use --force to delete `+
+					`them without confirmation.`,
+			)
+		}
+	}
+
+	for _, file := range removed {
+		err := client.DeleteFile(project, file.FileURI)
+		if err != nil {
+			return hierr.Errorf(
+				err,
+				`unable to delete file "%s"`,
+				file.FileURI,
+			)
+		}
+
+		if report != nil {
+			report.Add(PushReportEntry{
+				URI:     file.FileURI,
+				Type:    string(file.FileType),
+				Status:  "deleted",
+				Deleted: true,
+			})
+		}
+
+		fmt.Printf("%s deleted\n", file.FileURI)
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"bytes"
+	"regexp"
+
+	"github.com/Smartling/api-sdk-go"
+)
+
+// fileTypeDetectionSize is size of file head, which is inspected to detect
+// file type from its contents.
+const fileTypeDetectionSize = 512
+
+var gettextMessagePattern = regexp.MustCompile(`(?m)^msgid\s+"`)
+
+// detectFileType guesses file type from first bytes of file contents, which
+// is used when file type can't be deduced from extension. Unknown type is
+// returned if contents don't look like any supported format.
+func detectFileType(contents []byte) smartling.FileType {
+	head := contents
+	if len(head) > fileTypeDetectionSize {
+		head = head[:fileTypeDetectionSize]
+	}
+
+	head = bytes.TrimPrefix(head, []byte("\xef\xbb\xbf"))
+	head = bytes.TrimSpace(head)
+
+	lower := bytes.ToLower(head)
+
+	switch {
+	case bytes.HasPrefix(head, []byte("{")), bytes.HasPrefix(head, []byte("[")):
+		return smartling.FileTypeJSON
+
+	case bytes.HasPrefix(lower, []byte("<!doctype html")),
+		bytes.HasPrefix(lower, []byte("<html")):
+		return smartling.FileTypeHTML
+
+	case bytes.HasPrefix(head, []byte("<?xml")):
+		if bytes.Contains(head, []byte("<resources")) {
+			return smartling.FileTypeAndroid
+		}
+
+		return smartling.FileTypeXML
+
+	case gettextMessagePattern.Match(head):
+		return smartling.FileTypeGettext
+	}
+
+	return smartling.FileTypeUnknown
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/Smartling/api-sdk-go"
+	"github.com/reconquest/hierr-go"
+	"gopkg.in/yaml.v2"
+)
+
+// stringEntry is a single string of JSON or YAML file, addressed by path of
+// keys joined with dots.
+type stringEntry struct {
+	Key   string
+	Value string
+}
+
+// flattenStrings parses JSON or YAML document and returns all its leaf
+// values. Top-level value can be either object or array. Keys of objects
+// are sorted, since decoded maps do not keep order of keys.
+func flattenStrings(data []byte) ([]stringEntry, error) {
+	// JSON document is valid YAML document, see mergeWithSource
+	var document interface{}
+
+	err := yaml.Unmarshal(data, &document)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []stringEntry
+
+	// empty document, e.g. missing remote file, has no strings at all
+	if document == nil {
+		return result, nil
+	}
+
+	flattenValue(&result, "", document)
+
+	return result, nil
+}
+
+func flattenValue(result *[]stringEntry, prefix string, value interface{}) {
+	switch value := value.(type) {
+	case map[interface{}]interface{}:
+		keys := []string{}
+		items := map[string]interface{}{}
+
+		for key, item := range value {
+			keys = append(keys, fmt.Sprint(key))
+			items[fmt.Sprint(key)] = item
+		}
+
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			path := key
+			if prefix != "" {
+				path = prefix + "." + key
+			}
+
+			flattenValue(result, path, items[key])
+		}
+
+	case []interface{}:
+		for index, item := range value {
+			flattenValue(result, fmt.Sprintf("%s[%d]", prefix, index), item)
+		}
+
+	case nil:
+		*result = append(*result, stringEntry{Key: prefix})
+
+	default:
+		*result = append(*result, stringEntry{
+			Key:   prefix,
+			Value: fmt.Sprint(value),
+		})
+	}
+}
+
+// diffFileStrings downloads original file from project and prints keys,
+// which were added, removed or changed in local file, in unified diff style.
+func diffFileStrings(
+	client *smartling.Client,
+	config Config,
+	request *smartling.FileUploadRequest,
+	path string,
+) (int, int, int, error) {
+	var (
+		project = config.ProjectID
+		uri     = request.FileURI
+	)
+
+	var remote []byte
+
+	reader, err := client.DownloadFile(project, uri)
+	if err != nil {
+		if _, ok := err.(smartling.NotFoundError); !ok {
+			return 0, 0, 0, hierr.Errorf(
+				err,
+				`unable to download original file "%s" from project "%s"`,
+				uri,
+				project,
+			)
+		}
+	} else {
+		remote, err = ioutil.ReadAll(reader)
+		if err != nil {
+			return 0, 0, 0, hierr.Errorf(
+				err,
+				`unable to read original file "%s" contents`,
+				uri,
+			)
+		}
+	}
+
+	before, err := flattenStrings(remote)
+	if err != nil {
+		return 0, 0, 0, hierr.Errorf(
+			err,
+			`unable to parse original file "%s"`,
+			uri,
+		)
+	}
+
+	after, err := flattenStrings(request.File)
+	if err != nil {
+		return 0, 0, 0, hierr.Errorf(
+			err,
+			`unable to parse local file "%s"`,
+			path,
+		)
+	}
+
+	var (
+		values = map[string]string{}
+		lines  []string
+
+		added, removed, changed int
+	)
+
+	for _, entry := range before {
+		values[entry.Key] = entry.Value
+	}
+
+	for _, entry := range after {
+		value, ok := values[entry.Key]
+
+		switch {
+		case !ok:
+			lines = append(lines, formatStringLine("+", entry.Key, entry.Value))
+			added++
+
+		case value != entry.Value:
+			lines = append(
+				lines,
+				formatStringLine("-", entry.Key, value),
+				formatStringLine("+", entry.Key, entry.Value),
+			)
+			changed++
+		}
+
+		delete(values, entry.Key)
+	}
+
+	for _, entry := range before {
+		if _, ok := values[entry.Key]; !ok {
+			continue
+		}
+
+		lines = append(lines, formatStringLine("-", entry.Key, entry.Value))
+		removed++
+	}
+
+	if len(lines) == 0 {
+		return 0, 0, 0, nil
+	}
+
+	fmt.Printf("--- %s\n", uri)
+	fmt.Printf("+++ %s\n", path)
+	fmt.Println(strings.Join(lines, "\n"))
+
+	return added, removed, changed, nil
+}
+
+func formatStringLine(sign string, key string, value string) string {
+	return fmt.Sprintf("%s%s: %q", sign, key, value)
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/reconquest/hierr-go"
+	"gopkg.in/yaml.v2"
+)
+
+// maskedValue is displayed instead of secret values.
+const maskedValue = "********"
+
+type ResolvedFile struct {
+	Path       string            `json:"path" yaml:"path"`
+	URI        string            `json:"uri" yaml:"uri"`
+	Type       string            `json:"type" yaml:"type"`
+	Directives map[string]string `json:"directives,omitempty" yaml:"directives,omitempty"`
+}
+
+type ResolvedConfig struct {
+	Path       string                `json:"path" yaml:"path"`
+	UserID     string                `json:"user_id" yaml:"user_id"`
+	Secret     string                `json:"secret" yaml:"secret"`
+	AccountID  string                `json:"account_id" yaml:"account_id"`
+	ProjectID  string                `json:"project_id" yaml:"project_id"`
+	Threads    int                   `json:"threads" yaml:"threads"`
+	Downloads  int                   `json:"parallel_downloads" yaml:"parallel_downloads"`
+	Uploads    int                   `json:"parallel_uploads" yaml:"parallel_uploads"`
+	RetryCount int                   `json:"retry_count" yaml:"retry_count"`
+	RetryDelay string                `json:"retry_delay" yaml:"retry_delay"`
+	Timeout    string                `json:"timeout" yaml:"timeout"`
+	Proxy      string                `json:"proxy,omitempty" yaml:"proxy,omitempty"`
+	LocaleMap  map[string]string     `json:"locale_map,omitempty" yaml:"locale_map,omitempty"`
+	Branch     string                `json:"branch,omitempty" yaml:"branch,omitempty"`
+	Files      map[string]FileConfig `json:"files,omitempty" yaml:"files,omitempty"`
+	Push       []ResolvedFile        `json:"push" yaml:"push"`
+}
+
+func doConfigShow(config Config, args map[string]interface{}) error {
+	var (
+		branch, _ = args["--branch"].(string)
+		output, _ = args["--output"].(string)
+	)
+
+	branch, err := resolveBranch(branch)
+	if err != nil {
+		return err
+	}
+
+	args["--branch"] = branch
+
+	path, err := filepath.Abs(config.path)
+	if err != nil {
+		path = config.path
+	}
+
+	resolved := ResolvedConfig{
+		Path:       path,
+		UserID:     config.UserID,
+		AccountID:  config.AccountID,
+		ProjectID:  config.ProjectID,
+		Threads:    config.Threads,
+		Downloads:  config.ParallelDownloads,
+		Uploads:    config.ParallelUploads,
+		RetryCount: *config.RetryCount,
+		RetryDelay: config.RetryDelay.String(),
+		Timeout:    config.Timeout.String(),
+		Proxy:      config.Proxy,
+		LocaleMap:  config.LocaleMap,
+		Branch:     branch,
+		Files:      config.Files,
+		Push:       []ResolvedFile{},
+	}
+
+	if config.Secret != "" {
+		resolved.Secret = maskedValue
+	}
+
+	if proxy, err := url.Parse(config.Proxy); err == nil && proxy.User != nil {
+		if _, ok := proxy.User.Password(); ok {
+			proxy.User = url.UserPassword(proxy.User.Username(), maskedValue)
+			resolved.Proxy = proxy.String()
+		}
+	}
+
+	hasPushPatterns := false
+	for _, section := range config.Files {
+		if section.Push.Type != "" {
+			hasPushPatterns = true
+		}
+	}
+
+	if hasPushPatterns {
+		base, files, err := globFilesToPush(config, args)
+		if err != nil {
+			return err
+		}
+
+		for _, file := range files {
+			request, err := buildUploadRequest(config, args, base, file)
+			if err != nil {
+				return err
+			}
+
+			resolved.Push = append(resolved.Push, ResolvedFile{
+				Path:       file,
+				URI:        request.FileURI,
+				Type:       string(request.FileType),
+				Directives: request.Smartling.Directives,
+			})
+		}
+	}
+
+	switch output {
+	case "", "yaml":
+		data, err := yaml.Marshal(resolved)
+		if err != nil {
+			return hierr.Errorf(err, "unable to write config as YAML")
+		}
+
+		_, err = os.Stdout.Write(data)
+		if err != nil {
+			return hierr.Errorf(err, "unable to write config to stdout")
+		}
+
+		return nil
+
+	case "json":
+		return writeJSON(os.Stdout, resolved)
+
+	default:
+		return NewError(
+			fmt.Errorf(`unknown output type: %q`, output),
+
+			`Output type should be either "yaml" or "json".`,
+		)
+	}
+}
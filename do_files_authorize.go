@@ -0,0 +1,264 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"strconv"
+	"time"
+
+	"github.com/Smartling/api-sdk-go"
+	"github.com/reconquest/hierr-go"
+)
+
+const (
+	authorizeWaitInterval = 5 * time.Second
+)
+
+func doFilesAuthorize(
+	client *smartling.Client,
+	config Config,
+	args map[string]interface{},
+) error {
+	var (
+		project        = config.ProjectID
+		uri, _         = args["<uri>"].(string)
+		locales, _     = args["--locale"].([]string)
+		wait           = args["--wait"].(bool)
+		waitTimeout, _ = args["--wait-timeout"].(string)
+	)
+
+	var timeout time.Duration
+
+	if waitTimeout != "" {
+		seconds, err := strconv.Atoi(waitTimeout)
+		if err == nil {
+			timeout = time.Duration(seconds) * time.Second
+		} else {
+			timeout, err = time.ParseDuration(waitTimeout)
+		}
+
+		if err != nil || timeout <= 0 {
+			return NewError(
+				fmt.Errorf(`invalid --wait-timeout value: %q`, waitTimeout),
+
+				`Timeout should be number of seconds or duration, e.g. 10m.`,
+			)
+		}
+	}
+
+	if len(locales) > 0 {
+		locales = splitLocales(locales)
+
+		err := checkLocales(client, config, locales)
+		if err != nil {
+			return err
+		}
+	}
+
+	files, err := globFilesRemote(client, project, uri)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		awaiting, err := getFileAwaitingCount(client, project, file, locales)
+		if err != nil {
+			return err
+		}
+
+		if awaiting == 0 {
+			fmt.Printf("%s nothing to authorize\n", file.FileURI)
+
+			continue
+		}
+
+		err = authorizeFile(client, config, file, locales)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("%s authorized [%d strings]\n", file.FileURI, awaiting)
+	}
+
+	if !wait {
+		return nil
+	}
+
+	return waitFilesAuthorized(client, project, files, locales, timeout)
+}
+
+// waitFilesAuthorized polls status of given files until no strings are
+// awaiting authorization, until timeout is reached, if it's given, or
+// until SIGINT is received.
+func waitFilesAuthorized(
+	client *smartling.Client,
+	project string,
+	files []smartling.File,
+	locales []string,
+	timeout time.Duration,
+) error {
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+
+	var deadline <-chan time.Time
+
+	if timeout > 0 {
+		deadline = time.After(timeout)
+	}
+
+	for {
+		var awaiting int
+
+		for _, file := range files {
+			count, err := getFileAwaitingCount(client, project, file, locales)
+			if err != nil {
+				return err
+			}
+
+			awaiting += count
+		}
+
+		if awaiting == 0 {
+			break
+		}
+
+		fmt.Printf(
+			"%d strings are still awaiting authorization, checking again "+
+				"in %s, press Ctrl+C to stop\n",
+			awaiting,
+			authorizeWaitInterval,
+		)
+
+		select {
+		case <-interrupt:
+			return NewError(
+				fmt.Errorf(
+					`waiting is interrupted, %d strings are still awaiting `+
+						`authorization`,
+					awaiting,
+				),
+
+				`Authorization is processed asynchronously, so strings will `+
+					`be authorized later anyway.`,
+			)
+
+		case <-deadline:
+			return NewError(
+				fmt.Errorf(
+					`%d strings are still awaiting authorization after %s`,
+					awaiting,
+					timeout,
+				),
+
+				`Authorization is processed asynchronously, so strings will `+
+					`be authorized later anyway. Increase --wait-timeout to `+
+					`wait longer.`,
+			)
+
+		case <-time.After(authorizeWaitInterval):
+		}
+	}
+
+	fmt.Println("all strings are authorized")
+
+	return nil
+}
+
+func getFileAwaitingCount(
+	client *smartling.Client,
+	project string,
+	file smartling.File,
+	locales []string,
+) (int, error) {
+	status, err := client.GetFileStatus(project, file.FileURI)
+	if err != nil {
+		return 0, hierr.Errorf(
+			err,
+			`unable to retrieve file "%s" status from project "%s"`,
+			file.FileURI,
+			project,
+		)
+	}
+
+	var awaiting int
+
+	for _, translation := range status.Items {
+		if len(locales) > 0 {
+			if !hasLocaleInList(translation.LocaleID, locales) {
+				continue
+			}
+		}
+
+		awaiting += getAwaitingAuthorizationCount(status, translation)
+	}
+
+	return awaiting, nil
+}
+
+// authorizeFile uploads original file contents back with authorization
+// flags, because API client does not expose separate authorization
+// endpoint. Contents are not changed, so no strings are added or removed,
+// but file gets new upload time, and directives and namespace from config
+// file are applied again, same as on push.
+func authorizeFile(
+	client *smartling.Client,
+	config Config,
+	file smartling.File,
+	locales []string,
+) error {
+	var project = config.ProjectID
+
+	reader, err := client.DownloadFile(project, file.FileURI)
+	if err != nil {
+		return hierr.Errorf(
+			err,
+			`unable to download original file "%s" from project "%s"`,
+			file.FileURI,
+			project,
+		)
+	}
+
+	contents, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return hierr.Errorf(
+			err,
+			`unable to read original file "%s" contents`,
+			file.FileURI,
+		)
+	}
+
+	fileConfig, err := config.GetFileConfig(file.FileURI)
+	if err != nil {
+		return err
+	}
+
+	request := smartling.FileUploadRequest{
+		File:               contents,
+		FileType:           file.FileType,
+		Authorize:          len(locales) == 0,
+		LocalesToAuthorize: locales,
+	}
+
+	request.FileURI = file.FileURI
+	request.Smartling.Namespace = fileConfig.Push.Namespace
+	request.Smartling.Directives = fileConfig.Push.Directives
+
+	_, err = client.UploadFile(project, request)
+	if err != nil {
+		return NewError(
+			hierr.Errorf(
+				err,
+				`unable to authorize file "%s"`,
+				file.FileURI,
+			),
+
+			`Check, that you have enough permissions to upload file to`+
+				` the specified project`,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Smartling/api-sdk-go"
+	"github.com/reconquest/hierr-go"
+)
+
+func doFilesClean(
+	client *smartling.Client,
+	config Config,
+	args map[string]interface{},
+) error {
+	var (
+		directory    = args["--directory"].(string)
+		dryRun       = args["--dry-run"].(bool)
+		allPulled, _ = args["--all-pulled"].(bool)
+		manifest, _  = args["--checksum-file"].(string)
+	)
+
+	var paths []string
+
+	if config.path != "" {
+		paths = append(
+			paths,
+			filepath.Join(filepath.Dir(config.path), fileHashesName),
+		)
+	}
+
+	if manifest != "" {
+		paths = append(paths, manifest)
+	}
+
+	if allPulled {
+		pulled, err := getPulledFiles(client, config, args)
+		if err != nil {
+			return err
+		}
+
+		paths = append(paths, pulled...)
+	}
+
+	checksums, err := findChecksumFiles(directory)
+	if err != nil {
+		return err
+	}
+
+	paths = append(paths, checksums...)
+
+	var (
+		seen  = map[string]bool{}
+		count = 0
+	)
+
+	for _, path := range paths {
+		if seen[path] || !isFileExists(path) {
+			continue
+		}
+
+		seen[path] = true
+		count++
+
+		if dryRun {
+			fmt.Printf("%s will be removed\n", path)
+
+			continue
+		}
+
+		err := os.Remove(path)
+		if err != nil {
+			return hierr.Errorf(err, `unable to remove "%s"`, path)
+		}
+
+		fmt.Printf("%s removed\n", path)
+	}
+
+	if dryRun {
+		fmt.Printf("%d files will be removed\n", count)
+	} else {
+		fmt.Printf("%d files removed\n", count)
+	}
+
+	return nil
+}
+
+// findChecksumFiles returns "<file>.sha256" files written by pull --checksum
+// in specified directory. Other files with the same extension are left
+// intact.
+func findChecksumFiles(directory string) ([]string, error) {
+	var result []string
+
+	err := filepath.Walk(
+		directory,
+		func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if info.IsDir() || !strings.HasSuffix(path, ".sha256") {
+				return nil
+			}
+
+			data, err := ioutil.ReadFile(path)
+			if err != nil {
+				return err
+			}
+
+			fields := strings.Fields(string(data))
+			if len(fields) != 2 {
+				return nil
+			}
+
+			if fields[1] != strings.TrimSuffix(filepath.Base(path), ".sha256") {
+				return nil
+			}
+
+			result = append(result, path)
+
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, hierr.Errorf(
+			err,
+			`unable to find checksum files in "%s"`,
+			directory,
+		)
+	}
+
+	return result, nil
+}
+
+// getPulledFiles returns local paths of translations of project files, which
+// are written by pull command with the same --directory and --format.
+// Source files are never returned.
+func getPulledFiles(
+	client *smartling.Client,
+	config Config,
+	args map[string]interface{},
+) ([]string, error) {
+	var (
+		project   = config.ProjectID
+		uri, _    = args["<uri>"].(string)
+		directory = args["--directory"].(string)
+
+		format, formatGiven = args["--format"].(string)
+	)
+
+	if format == "" {
+		format = defaultFilePullFormat
+	}
+
+	useFormat := usePullFormat
+	if formatGiven {
+		useFormat = func(FileConfig) string {
+			return format
+		}
+	}
+
+	files, err := globFilesRemote(client, project, uri)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []string
+
+	for _, file := range files {
+		status, err := client.GetFileStatus(project, file.FileURI)
+		if err != nil {
+			return nil, hierr.Errorf(
+				err,
+				`unable to retrieve file "%s" locales from project "%s"`,
+				file.FileURI,
+				project,
+			)
+		}
+
+		for _, translation := range status.Items {
+			path, err := executeFileFormat(
+				config,
+				file,
+				format,
+				useFormat,
+				map[string]interface{}{
+					"FileURI": file.FileURI,
+					"Locale":  config.MapLocale(translation.LocaleID),
+				},
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			result = append(result, filepath.Join(directory, path))
+		}
+	}
+
+	return result, nil
+}
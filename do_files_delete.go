@@ -13,14 +13,26 @@ func doFilesDelete(
 	args map[string]interface{},
 ) error {
 	var (
-		project = config.ProjectID
-		uri     = args["<uri>"].(string)
+		project   = config.ProjectID
+		uri, _    = args["<uri>"].(string)
+		branch, _ = args["--branch"].(string)
+		dryRun    = args["--dry-run"].(bool)
 	)
 
-	var (
-		err   error
-		files []smartling.File
-	)
+	if uri == "" && branch == "" {
+		return NewError(
+			fmt.Errorf("no files to delete are specified"),
+
+			`Specify either <uri> pattern or --branch option.`,
+		)
+	}
+
+	branch, err := resolveBranch(branch)
+	if err != nil {
+		return err
+	}
+
+	var files []smartling.File
 
 	if uri == "-" {
 		files, err = readFilesFromStdin()
@@ -34,6 +46,11 @@ func doFilesDelete(
 		}
 	}
 
+	files, err = filterFilesByBranch(files, branch)
+	if err != nil {
+		return err
+	}
+
 	if len(files) == 0 {
 		return NewError(
 			fmt.Errorf("no files match specified pattern"),
@@ -44,6 +61,12 @@ func doFilesDelete(
 	}
 
 	for _, file := range files {
+		if dryRun {
+			fmt.Printf("%s will be deleted\n", file.FileURI)
+
+			continue
+		}
+
 		err := client.DeleteFile(project, file.FileURI)
 		if err != nil {
 			return hierr.Errorf(
@@ -56,5 +79,11 @@ func doFilesDelete(
 		fmt.Printf("%s deleted\n", file.FileURI)
 	}
 
+	if dryRun {
+		fmt.Printf("%d files will be deleted\n", len(files))
+	} else {
+		fmt.Printf("%d files deleted\n", len(files))
+	}
+
 	return nil
 }
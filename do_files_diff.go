@@ -0,0 +1,291 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/Smartling/api-sdk-go"
+	"github.com/reconquest/hierr-go"
+)
+
+// diffURIPrefix is prefix for temporary files which are uploaded to compare
+// local files with ones in project.
+const diffURIPrefix = "smartling-cli/diff/"
+
+func doFilesDiff(
+	client *smartling.Client,
+	config Config,
+	args map[string]interface{},
+) error {
+	var (
+		branch, _ = args["--branch"].(string)
+		perString = args["--strings"].(bool)
+	)
+
+	branch, err := resolveBranch(branch)
+	if err != nil {
+		return err
+	}
+
+	args["--branch"] = branch
+
+	base, files, err := globFilesToPush(config, args)
+	if err != nil {
+		return err
+	}
+
+	var changed, added, removed, modified int
+
+	// interrupt is checked only between files, so temporary file, which
+	// is uploaded for comparison, is always deleted before exit
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+
+	for _, file := range files {
+		select {
+		case <-interrupt:
+			return NewError(
+				fmt.Errorf(`comparison is interrupted`),
+
+				`Temporary files, which were uploaded for comparison, `+
+					`have been deleted.`,
+			)
+
+		default:
+		}
+
+		request, err := buildUploadRequest(config, args, base, file)
+		if err != nil {
+			return err
+		}
+
+		if perString {
+			if !isMergeWithSourceSupported(request.FileType) {
+				logger.Warningf(
+					"%s: per-string diff is not supported for %s files, skipping",
+					file,
+					request.FileType,
+				)
+
+				continue
+			}
+
+			fileAdded, fileRemoved, fileModified, err := diffFileStrings(
+				client,
+				config,
+				request,
+				file,
+			)
+			if err != nil {
+				return err
+			}
+
+			if fileAdded+fileRemoved+fileModified > 0 {
+				changed++
+			}
+
+			added += fileAdded
+			removed += fileRemoved
+			modified += fileModified
+
+			continue
+		}
+
+		fileAdded, fileRemoved, err := diffFile(client, config, request)
+		if err != nil {
+			return err
+		}
+
+		if fileAdded == 0 && fileRemoved == 0 {
+			continue
+		}
+
+		fmt.Printf("%s | +%d -%d\n", request.FileURI, fileAdded, fileRemoved)
+
+		changed++
+		added += fileAdded
+		removed += fileRemoved
+	}
+
+	if perString {
+		fmt.Printf(
+			"%d files changed, %d strings added, %d strings removed, "+
+				"%d strings changed\n",
+			changed,
+			added,
+			removed,
+			modified,
+		)
+
+		return nil
+	}
+
+	fmt.Printf(
+		"%d files changed, %d strings added, %d strings removed\n",
+		changed,
+		added,
+		removed,
+	)
+
+	return nil
+}
+
+func diffFile(
+	client *smartling.Client,
+	config Config,
+	request *smartling.FileUploadRequest,
+) (int, int, error) {
+	var (
+		project = config.ProjectID
+		uri     = request.FileURI
+	)
+
+	original, err := client.GetFileStatus(project, uri)
+	if err != nil {
+		if _, ok := err.(smartling.NotFoundError); !ok {
+			return 0, 0, NewError(
+				hierr.Errorf(
+					err,
+					`unable to get file "%s" status`,
+					uri,
+				),
+
+				`Check, that you have enough permissions to access the `+
+					`specified project.`,
+			)
+		}
+
+		original = nil
+	}
+
+	// request is copied, so caller still sees original URI
+	upload := *request
+
+	upload.FileURI = fmt.Sprintf(
+		"%s%d/%s",
+		diffURIPrefix,
+		time.Now().UnixNano(),
+		uri,
+	)
+
+	upload.Authorize = false
+	upload.LocalesToAuthorize = nil
+
+	// temporary file is deleted even if upload has failed, because
+	// upload can fail after file has been already created
+	defer func() {
+		logger.Infof("deleting %s", upload.FileURI)
+
+		err := client.DeleteFile(project, upload.FileURI)
+		if err != nil {
+			if _, ok := err.(smartling.NotFoundError); ok {
+				return
+			}
+
+			logger.Errorf(
+				"unable to delete temporary file %q: %s",
+				upload.FileURI,
+				err,
+			)
+		}
+	}()
+
+	logger.Infof("uploading %s as %s", uri, upload.FileURI)
+
+	response, err := client.UploadFile(project, upload)
+	if err != nil {
+		return 0, 0, NewError(
+			hierr.Errorf(
+				err,
+				`unable to upload file "%s" for comparison`,
+				uri,
+			),
+
+			`Check, that you have enough permissions to upload file to`+
+				` the specified project`,
+		)
+	}
+
+	// file is not present in project, so all its strings are new
+	if original == nil {
+		return response.StringCount, 0, nil
+	}
+
+	status, err := client.GetFileStatus(project, upload.FileURI)
+	if err != nil {
+		return 0, 0, NewError(
+			hierr.Errorf(
+				err,
+				`unable to get status of temporary file "%s"`,
+				upload.FileURI,
+			),
+
+			`Check, that you have enough permissions to access the `+
+				`specified project.`,
+		)
+	}
+
+	added := getAddedStringCount(original, status)
+
+	removed := original.TotalStringCount - (response.StringCount - added)
+	if removed < 0 {
+		removed = 0
+	}
+
+	return added, removed, nil
+}
+
+// getAddedStringCount estimates count of strings, which are present in
+// temporary file, but not in original file.
+//
+// Strings, which are already known to project, keep their authorization,
+// exclusion and translation in temporary file, and new strings are awaiting
+// authorization in every locale. So, for every locale, count of strings
+// awaiting authorization in temporary file minus same count in original file
+// is count of new strings minus count of removed strings, which were not
+// authorized in that locale. Strings, which were not authorized before
+// change, are cancelled out this way. Maximum over locales is taken, because
+// it is exact for any locale where all removed strings were authorized.
+//
+// If files have no locales in common, difference of total counts is used.
+func getAddedStringCount(
+	original *smartling.FileStatus,
+	status *smartling.FileStatus,
+) int {
+	awaiting := map[string]int{}
+
+	for _, translation := range original.Items {
+		awaiting[translation.LocaleID] = getAwaitingAuthorizationCount(
+			original,
+			translation,
+		)
+	}
+
+	var (
+		added  = 0
+		common = false
+	)
+
+	for _, translation := range status.Items {
+		before, ok := awaiting[translation.LocaleID]
+		if !ok {
+			continue
+		}
+
+		common = true
+
+		count := getAwaitingAuthorizationCount(status, translation) - before
+		if count > added {
+			added = count
+		}
+	}
+
+	if !common && status.TotalStringCount > original.TotalStringCount {
+		added = status.TotalStringCount - original.TotalStringCount
+	}
+
+	return added
+}
@@ -9,17 +9,37 @@ import (
 	"github.com/reconquest/hierr-go"
 )
 
+type FilesListRow struct {
+	FileURI      string `json:"uri"`
+	FileType     string `json:"type"`
+	LastUploaded string `json:"last_uploaded"`
+}
+
 func doFilesList(
 	client *smartling.Client,
 	config Config,
 	args map[string]interface{},
 ) error {
 	var (
-		project = config.ProjectID
-		short   = args["--short"].(bool)
-		uri, _  = args["<uri>"].(string)
+		project   = config.ProjectID
+		short     = args["--short"].(bool)
+		uri, _    = args["<uri>"].(string)
+		branch, _ = args["--branch"].(string)
+		output, _ = args["--output"].(string)
 	)
 
+	switch output {
+	case "", "table", "json":
+		// ok
+
+	default:
+		return NewError(
+			fmt.Errorf(`unknown output type: "%s"`, output),
+
+			`Output type should be one of: table or json.`,
+		)
+	}
+
 	if args["--format"] == nil {
 		args["--format"] = defaultFilesListFormat
 	}
@@ -29,11 +49,35 @@ func doFilesList(
 		return err
 	}
 
+	branch, err = resolveBranch(branch)
+	if err != nil {
+		return err
+	}
+
 	files, err := globFilesRemote(client, project, uri)
 	if err != nil {
 		return err
 	}
 
+	files, err = filterFilesByBranch(files, branch)
+	if err != nil {
+		return err
+	}
+
+	if output == "json" {
+		rows := []FilesListRow{}
+
+		for _, file := range files {
+			rows = append(rows, FilesListRow{
+				FileURI:      file.FileURI,
+				FileType:     string(file.FileType),
+				LastUploaded: fmt.Sprint(file.LastUploaded),
+			})
+		}
+
+		return writeJSON(os.Stdout, rows)
+	}
+
 	table := NewTableWriter(os.Stdout)
 
 	for _, file := range files {
@@ -1,7 +1,12 @@
 package main
 
 import (
+	"fmt"
+	"regexp"
+	"sync/atomic"
+
 	"github.com/Smartling/api-sdk-go"
+	"github.com/reconquest/hierr-go"
 )
 
 func doFilesPull(
@@ -10,12 +15,39 @@ func doFilesPull(
 	args map[string]interface{},
 ) error {
 	var (
-		project = config.ProjectID
-		uri, _  = args["<uri>"].(string)
+		project     = config.ProjectID
+		uri, _      = args["<uri>"].(string)
+		locales, _  = args["--locale"].([]string)
+		excludes, _ = args["--exclude"].([]string)
+		only, _     = args["--file"].([]string)
+
+		localeFilter, _ = args["--locale-filter-regexp"].(string)
+		onMissing, _    = args["--on-missing-file"].(string)
+
+		localeSubdirectory, _ = args["--locale-subdirectory"].(bool)
+
+		options PullOptions
 	)
 
-	if args["--format"] == nil {
-		args["--format"] = defaultFilePullFormat
+	if uri != "" && len(only) > 0 {
+		return NewError(
+			fmt.Errorf(`--file can not be used along with <uri> pattern`),
+
+			`Use --file to pull translations of some source files or <uri> `+
+				`to pull files by pattern.`,
+		)
+	}
+
+	switch onMissing {
+	case "", "skip", "create-empty", "error":
+		// valid
+
+	default:
+		return NewError(
+			fmt.Errorf(`unknown --on-missing-file value: %q`, onMissing),
+
+			`Value should be one of "skip", "create-empty" or "error".`,
+		)
 	}
 
 	var (
@@ -23,6 +55,172 @@ func doFilesPull(
 		files []smartling.File
 	)
 
+	if localeSubdirectory {
+		if args["--format"] != nil {
+			return NewError(
+				fmt.Errorf(
+					`--locale-subdirectory can not be used along with --format`,
+				),
+
+				`Use either --locale-subdirectory to store translations as `+
+					`<locale>/<file> or --format to specify own format.`,
+			)
+		}
+
+		args["--format"] = localeSubdirectoryPullFormat
+	}
+
+	// --format overrides pull.format from config file, so it should be
+	// checked before anything is downloaded
+	if format, ok := args["--format"].(string); ok {
+		_, err = compileFormat(format)
+		if err != nil {
+			return err
+		}
+	}
+
+	if manifest, ok := args["--checksum-file"].(string); ok {
+		checksum, _ := args["--checksum"].(bool)
+		verify, _ := args["--verify"].(bool)
+
+		if !checksum && !verify {
+			return NewError(
+				fmt.Errorf(
+					`--checksum-file can be used only along with --checksum `+
+						`or --verify`,
+				),
+
+				`Use --checksum to store checksums into specified file and `+
+					`--verify to check local files against it.`,
+			)
+		}
+
+		options.Checksums, err = loadFileHashes(manifest, defaultHashAlgorithm)
+		if err != nil {
+			return err
+		}
+	}
+
+	if atomicWrites, _ := args["--atomic"].(bool); atomicWrites {
+		if args["--post-process"] != nil {
+			return NewError(
+				fmt.Errorf(
+					`--atomic can not be used along with --post-process`,
+				),
+
+				`Files are written only after all downloads are completed, `+
+					`so they can't be processed one by one. Run command `+
+					`on pulled files after pull instead.`,
+			)
+		}
+
+		options.Pending = &PendingWrites{}
+	}
+
+	encodingName, _ := args["--encoding"].(string)
+	bom, _ := args["--bom"].(bool)
+
+	options.Encoding, err = parseOutputEncoding(encodingName, bom)
+	if err != nil {
+		return NewError(
+			err,
+
+			`Supported encodings are UTF-8, UTF-16LE, UTF-16BE and Latin-1; `+
+				`byte order mark can be added only to UTF-8 and UTF-16.`,
+		)
+	}
+
+	includeOriginal, _ := args["--include-original"].(bool)
+
+	if sourceLocale, ok := args["--source-locale"].(string); ok {
+		if !includeOriginal {
+			return NewError(
+				fmt.Errorf(
+					`--source-locale can be used only along with `+
+						`--include-original`,
+				),
+
+				`Add --include-original to write source files under `+
+					`specified locale.`,
+			)
+		}
+
+		if sourceLocale == "" {
+			return NewError(
+				fmt.Errorf(`--source-locale can not be empty`),
+
+				`Specify source locale ID, e.g. en-US.`,
+			)
+		}
+
+		options.SourceLocale = sourceLocale
+	}
+
+	if includeOriginal {
+		if args["--source"].(bool) {
+			return NewError(
+				fmt.Errorf(
+					`--include-original can not be used along with --source`,
+				),
+
+				`Use --source to download only source files or `+
+					`--include-original to write them next to translations.`,
+			)
+		}
+
+		if options.SourceLocale == "" {
+			details, err := client.GetProjectDetails(project)
+			if err != nil {
+				return hierr.Errorf(
+					err,
+					`unable to get project "%s" details`,
+					project,
+				)
+			}
+
+			options.SourceLocale = details.SourceLocaleID
+		}
+	}
+
+	if fileMap, ok := args["--locale-file-map"].([]string); ok {
+		options.LocaleFileMap, err = parseLocaleFileMap(fileMap)
+		if err != nil {
+			return NewError(
+				err,
+
+				`Locale file map should be given as <locale>=<format> or `+
+					`as JSON file with locale IDs as keys and formats as `+
+					`values, e.g. {"pt-BR": "por/{{.FileURI}}"}.`,
+			)
+		}
+	}
+
+	if len(locales) > 0 {
+		locales = splitLocales(locales)
+
+		err = checkLocales(client, config, locales)
+		if err != nil {
+			return err
+		}
+
+		args["--locale"] = locales
+	}
+
+	if localeFilter != "" {
+		options.LocaleFilter, err = regexp.Compile(localeFilter)
+		if err != nil {
+			return NewError(
+				hierr.Errorf(
+					err,
+					`unable to compile --locale-filter-regexp: %q`,
+					localeFilter,
+				),
+
+				`Check, that specified regular expression is valid, e.g. "^fr-".`,
+			)
+		}
+	}
+
 	if uri == "-" {
 		files, err = readFilesFromStdin()
 		if err != nil {
@@ -35,22 +233,152 @@ func doFilesPull(
 		}
 	}
 
-	pool := NewThreadPool(config.Threads)
+	files, err = excludeFilesRemote(files, excludes)
+	if err != nil {
+		return err
+	}
 
-	for _, file := range files {
+	if len(only) > 0 {
+		files, err = filterFilesRemote(files, only)
+		if err != nil {
+			return err
+		}
+	}
+
+	pool := NewThreadPool(config.ParallelDownloads)
+
+	var (
+		mismatched, missing, failed int32
+
+		downloads = make([][]fileDownload, len(files))
+	)
+
+	// files to write are found before downloading, so progress displays
+	// total count of files from the start
+	for index, file := range files {
 		// func closure required to pass different file objects to goroutines
-		func(file smartling.File) {
+		func(index int, file smartling.File) {
 			pool.Do(func() {
-				err := downloadFileTranslations(client, config, args, file)
-
+				list, err := getFileDownloads(
+					client,
+					config,
+					args,
+					options,
+					file,
+				)
 				if err != nil {
+					atomic.AddInt32(&failed, 1)
+
 					logger.Error(err)
+
+					return
+				}
+
+				downloads[index] = list
+			})
+		}(index, file)
+	}
+
+	pool.Wait()
+
+	progress := &Progress{}
+
+	for _, list := range downloads {
+		progress.Total += len(list)
+	}
+
+	for index, file := range files {
+		// failed files are already reported
+		if downloads[index] == nil {
+			continue
+		}
+
+		func(index int, file smartling.File) {
+			pool.Do(func() {
+				err := downloadFileTranslations(
+					client,
+					config,
+					args,
+					options,
+					file,
+					downloads[index],
+					progress,
+				)
+
+				// errors are reported right away, but command fails only
+				// after all files are processed
+				switch err.(type) {
+				case nil:
+					return
+
+				case checksumMismatchError:
+					atomic.AddInt32(&mismatched, 1)
+
+				case missingFileError:
+					atomic.AddInt32(&missing, 1)
+
+				default:
+					atomic.AddInt32(&failed, 1)
 				}
+
+				logger.Error(err)
 			})
-		}(file)
+		}(index, file)
 	}
 
 	pool.Wait()
 
+	if pending := options.Pending; pending != nil {
+		if failed > 0 || missing > 0 {
+			discarded := pending.Discard()
+
+			logger.Warningf(
+				"none of %d downloaded files are written because of errors",
+				discarded,
+			)
+		} else {
+			written, err := pending.Commit()
+			if err != nil {
+				return NewError(
+					hierr.Errorf(
+						err,
+						`unable to write downloaded files, %d files are `+
+							`already written`,
+						written,
+					),
+
+					`Check, that you have permissions to write into `+
+						`target directory and run pull again.`,
+				)
+			}
+		}
+	}
+
+	if failed > 0 {
+		return NewError(
+			fmt.Errorf(`unable to pull %d of %d files`, failed, len(files)),
+
+			`Check errors above for details and run pull again.`,
+		)
+	}
+
+	if missing > 0 {
+		return NewError(
+			fmt.Errorf(`translations of %d files are not found in project`, missing),
+
+			`Make sure that files are uploaded and locales are enabled in `+
+				`project, or use --on-missing-file=skip to ignore them.`,
+		)
+	}
+
+	if mismatched > 0 {
+		return NewError(
+			fmt.Errorf(`checksum verification failed for %d files`, mismatched),
+
+			`Local files are missing or do not match checksums from last `+
+				`pull. Run pull with --checksum again to restore them.`,
+		)
+	}
+
 	return nil
 }
@@ -1,11 +1,11 @@
 package main
 
 import (
-	"errors"
 	"fmt"
-	"io/ioutil"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/Smartling/api-sdk-go"
 	"github.com/reconquest/hierr-go"
@@ -17,256 +17,443 @@ func doFilesPush(
 	args map[string]interface{},
 ) error {
 	var (
-		project       = config.ProjectID
-		file, _       = args["<file>"].(string)
-		uri, useURI   = args["<uri>"].(string)
-		branch, _     = args["--branch"].(string)
-		locales, _    = args["--locale"].([]string)
-		authorize     = args["--authorize"].(bool)
-		directory     = args["--directory"].(string)
-		fileType, _   = args["--type"].(string)
-		directives, _ = args["--directive"].([]string)
-	)
-
-	if branch == "@auto" {
-		var err error
-
-		branch, err = getGitBranch()
-		if err != nil {
-			return hierr.Errorf(
-				err,
-				"unable to autodetect branch name",
-			)
-		}
-
-		logger.Infof("autodetected branch name: %s", branch)
-	}
+		branch, _  = args["--branch"].(string)
+		watch, _   = args["--watch"].(bool)
+		check, _   = args["--check-only"].(bool)
+		locales, _ = args["--locale"].([]string)
 
-	if branch != "" {
-		branch = strings.TrimSuffix(branch, "/") + "/"
-	}
+		branches, _ = args["--branches"].(string)
+		simulate, _ = args["--simulate-locale"].(string)
 
-	patterns := []string{}
+		deleteRemoved, _ = args["--delete-removed"].(bool)
+		smartUpdate, _   = args["--smart-update"].(bool)
 
-	if file != "" {
-		patterns = append(patterns, file)
-	} else {
-		for pattern, section := range config.Files {
-			if section.Push.Type != "" {
-				patterns = append(patterns, pattern)
-			}
-		}
-	}
+		options PushOptions
+	)
 
-	files := []string{}
+	if len(locales) > 0 {
+		locales = splitLocales(locales)
 
-	for _, pattern := range patterns {
-		base, pattern := getDirectoryFromPattern(pattern)
-		chunk, err := globFilesLocally(
-			directory,
-			base,
-			pattern,
-		)
+		err := checkLocales(client, config, locales)
 		if err != nil {
-			return NewError(
-				hierr.Errorf(
-					err,
-					`unable to find matching files to upload`,
-				),
-
-				`Check, that specified pattern is valid and refer to help for`+
-					` more information about glob patterns.`,
-			)
+			return err
 		}
 
-		files = append(files, chunk...)
+		args["--locale"] = locales
 	}
 
-	if len(files) == 0 {
-		return NewError(
-			fmt.Errorf(`no files found by specified patterns`),
-
-			`Check command line pattern if any and configuration file for`+
-				` more patterns to search for.`,
-		)
-	}
-
-	if uri != "" && len(files) > 1 {
+	if branches != "" && (branch != "" || watch || check) {
 		return NewError(
 			fmt.Errorf(
-				`more than one file is matching speciifed pattern and <uri>`+
-					` is specified too`,
+				`--branches can not be used along with --branch, --watch `+
+					`or --check-only`,
 			),
 
-			`Either remove <uri> argument or make sure that only one file`+
-				` is matching mask.`,
+			`Use --branches to push files under several branch prefixes `+
+				`at once or --branch to push under single prefix.`,
 		)
 	}
 
-	base, err := filepath.Abs(config.path)
-	if err != nil {
+	// hashes are stored once for all files, so file pushed to one branch
+	// would be skipped as unchanged for the rest of branches
+	if branches != "" && smartUpdate {
 		return NewError(
-			hierr.Errorf(
-				err,
-				`unable to resolve absolute path to config`,
-			),
+			fmt.Errorf(`--branches can not be used along with --smart-update`),
 
-			`It's internal error, please, contact developer for more info`,
+			`Push files with --branch for every branch prefix separately `+
+				`to skip unchanged files, or use --force to push all files.`,
 		)
 	}
 
-	base = filepath.Dir(base)
+	if simulate != "" {
+		if branches != "" || check {
+			return NewError(
+				fmt.Errorf(
+					`--simulate-locale can not be used along with --branches `+
+						`or --check-only`,
+				),
+
+				`Push files under single branch prefix to write their `+
+					`pseudo translations.`,
+			)
+		}
 
-	for _, file := range files {
-		name, err := filepath.Abs(file)
+		err := checkLocales(client, config, []string{simulate})
 		if err != nil {
+			return err
+		}
+	}
+
+	if value, ok := args["--min-string-count"].(string); ok {
+		count, err := strconv.Atoi(value)
+		if err != nil || count < 0 {
 			return NewError(
-				hierr.Errorf(
-					err,
-					`unable to resolve absolute path to file: %q`,
-					file,
-				),
+				fmt.Errorf(`invalid --min-string-count value: %q`, value),
 
-				`Check, that file exists and you have proper permissions `+
-					`to access it.`,
+				`Specify minimum number of strings as non-negative integer.`,
 			)
 		}
 
-		if !filepath.HasPrefix(name, base) {
+		options.MinStringCount = count
+	}
+
+	if deleteRemoved {
+		file, _ := args["<file>"].(string)
+		only, _ := args["--file"].([]string)
+		excludes, _ := args["--exclude"].([]string)
+
+		if file != "" || len(only) > 0 || len(excludes) > 0 ||
+			args["--max-file-size"] != nil {
 			return NewError(
-				errors.New(
-					`you are trying to push file outside project directory`,
+				fmt.Errorf(
+					`--delete-removed can not be used along with <file>, `+
+						`--file, --exclude or --max-file-size`,
 				),
 
-				`Check file path and path to configuration file and try again.`,
+				`Files, which are not pushed, would be deleted from project, `+
+					`so all files from config file should be pushed.`,
 			)
 		}
 
-		name, err = filepath.Rel(base, name)
-		if err != nil {
+		if branches != "" || check {
 			return NewError(
-				hierr.Errorf(
-					err,
-					`unable to resolve relative path to file: %q`,
-					file,
+				fmt.Errorf(
+					`--delete-removed can not be used along with --branches `+
+						`or --check-only`,
 				),
 
-				`Check, that file exists and you have proper permissions `+
-					`to access it.`,
+				`Push files under single branch prefix to delete removed `+
+					`files under that prefix.`,
 			)
 		}
+	}
 
-		if !useURI {
-			uri = name
-		}
+	if path, ok := args["--report"].(string); ok {
+		options.Report = NewPushReport(path)
+	}
 
-		fileConfig, err := config.GetFileConfig(file)
+	// URI format is checked before anything is uploaded
+	if args["--uri-format"] != nil {
+		_, err := compileFormat(args["--uri-format"].(string))
 		if err != nil {
-			return NewError(
-				hierr.Errorf(
-					err,
-					`unable to retrieve file specific configuration`,
-				),
+			return err
+		}
+	}
 
-				``,
-			)
+	branch, err := resolveBranch(branch)
+	if err != nil {
+		return err
+	}
+
+	args["--branch"] = branch
+
+	// without branch prefix every file in project is considered removed
+	// unless it's pushed, so whole project could be wiped out
+	if deleteRemoved && branch == "" {
+		return NewError(
+			fmt.Errorf(`--delete-removed requires --branch to be specified`),
+
+			`Only files under specified branch prefix are deleted, so `+
+				`files pushed without branch prefix are never deleted.`,
+		)
+	}
+
+	base, files, err := globFilesToPush(config, args)
+	if err != nil {
+		return err
+	}
+
+	if branches != "" {
+		var resolved []string
+
+		for _, name := range strings.Split(branches, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+
+			name, err = resolveBranch(name)
+			if err != nil {
+				return err
+			}
+
+			resolved = append(resolved, name)
 		}
 
-		contents, err := ioutil.ReadFile(file)
+		err = pushBranches(client, config, args, options, base, files, resolved)
+
+		return writePushReport(options.Report, err)
+	}
+
+	if check {
+		return checkFilesToPush(client, config, args, base, files)
+	}
+
+	algorithm, algorithmGiven := args["--hash-algorithm"].(string)
+	if !algorithmGiven {
+		algorithm = defaultHashAlgorithm
+	}
+
+	if smartUpdate {
+		hashes, err := loadFileHashes(
+			filepath.Join(base, fileHashesName),
+			strings.ToLower(algorithm),
+		)
 		if err != nil {
 			return NewError(
-				hierr.Errorf(
-					err,
-					`unable to read file contents "%s"`,
-					file,
-				),
+				err,
 
-				`Check that file exists and readable by current user.`,
+				`Supported hash algorithms are sha256 (default), sha1, `+
+					`md5 and xxhash.`,
 			)
 		}
 
-		request := smartling.FileUploadRequest{
-			File:               contents,
-			Authorize:          authorize,
-			LocalesToAuthorize: locales,
-		}
+		options.Hashes = hashes
+	} else if algorithmGiven {
+		logger.Warningf("--hash-algorithm is ignored without --smart-update")
+	}
 
-		request.FileURI = branch + uri
+	var (
+		pool  = NewThreadPool(config.ParallelUploads)
+		mutex = sync.Mutex{}
 
-		if fileConfig.Push.Type == "" {
-			if fileType == "" {
-				request.FileType = smartling.GetFileTypeByExtension(
-					filepath.Ext(file),
-				)
+		failure error
+	)
 
-				if request.FileType == smartling.FileTypeUnknown {
-					return NewError(
-						fmt.Errorf(
-							"unable to deduce file type from extension: %q",
-							filepath.Ext(file),
-						),
+	for _, file := range files {
+		// func closure required to pass different file objects to goroutines
+		func(file string) {
+			pool.Do(func() {
+				mutex.Lock()
+				failed := failure != nil
+				mutex.Unlock()
+
+				// do not start new uploads after first failure
+				if failed {
+					return
+				}
 
-						`You need to specify file type via --type option.`,
-					)
+				err := pushFile(client, config, args, options, base, file)
+				if err == nil {
+					return
 				}
-			} else {
-				request.FileType = smartling.FileType(fileType)
-			}
+
+				mutex.Lock()
+				defer mutex.Unlock()
+
+				if failure == nil {
+					failure = err
+				} else {
+					logger.Error(err)
+				}
+			})
+		}(file)
+	}
+
+	pool.Wait()
+
+	// files are deleted only after successful push, so failed push never
+	// leaves project without files
+	if deleteRemoved {
+		if failure == nil {
+			failure = deleteRemovedFiles(
+				client,
+				config,
+				args,
+				options,
+				base,
+				files,
+			)
 		} else {
-			request.FileType = smartling.FileType(fileConfig.Push.Type)
+			logger.Warningf("push has failed, removed files are not deleted")
 		}
+	}
 
-		request.Smartling.Directives = fileConfig.Push.Directives
+	failure = writePushReport(options.Report, failure)
+	if failure != nil {
+		return failure
+	}
 
-		for _, directive := range directives {
-			spec := strings.SplitN(directive, "=", 2)
-			if len(spec) != 2 {
-				return NewError(
-					fmt.Errorf(
-						"invalid directive specification: %q",
-						directive,
-					),
+	if !watch {
+		return nil
+	}
 
-					`Should be in the form of <name>=<value>.`,
-				)
-			}
+	fmt.Println("watching for changes, press Ctrl+C to stop")
 
-			if request.Smartling.Directives == nil {
-				request.Smartling.Directives = map[string]string{}
-			}
+	return watchFiles(files, func(file string) {
+		logger.Infof("%s is changed, pushing", file)
 
-			request.Smartling.Directives[spec[0]] = spec[1]
+		err := pushFile(client, config, args, options, base, file)
+		if err != nil {
+			logger.Error(err)
 		}
+	})
+}
+
+func pushFile(
+	client *smartling.Client,
+	config Config,
+	args map[string]interface{},
+	options PushOptions,
+	base string,
+	file string,
+) (err error) {
+	var (
+		project     = config.ProjectID
+		branch, _   = args["--branch"].(string)
+		dryRun, _   = args["--dry-run"].(bool)
+		hashes      = options.Hashes
+		force, _    = args["--force"].(bool)
+		simulate, _ = args["--simulate-locale"].(string)
+		report      = options.Report
+		minCount    = options.MinStringCount
+
+		entry = PushReportEntry{File: file}
+	)
 
-		response, err := client.UploadFile(project, request)
+	// result is reported for every file, including failed ones
+	defer func() {
+		if report == nil {
+			return
+		}
 
 		if err != nil {
-			return NewError(
-				hierr.Errorf(
-					err,
-					`unable to upload file "%s"`,
-					file,
-				),
+			entry.Status = "failed"
+			entry.Error = err.Error()
+		}
+
+		report.Add(entry)
+	}()
+
+	request, err := buildUploadRequest(config, args, base, file)
+	if err != nil {
+		return err
+	}
+
+	entry.URI = request.FileURI
+	entry.Type = string(request.FileType)
 
-				`Check, that you have enough permissions to upload file to`+
-					` the specified project`,
+	if minCount > 0 {
+		count, ok, err := countLocalStrings(request.FileType, request.File)
+		if err != nil {
+			return hierr.Errorf(
+				err,
+				`unable to count strings in "%s"`,
+				file,
 			)
 		}
 
-		status := "new"
-		if response.Overwritten {
-			status = "overwritten"
+		if !ok {
+			logger.Warningf(
+				"unable to count strings in %s files, pushing %s anyway",
+				request.FileType,
+				file,
+			)
+		} else if count < minCount {
+			logger.Infof(
+				"skipping %s: %d strings is less than --min-string-count (%d)",
+				file,
+				count,
+				minCount,
+			)
+
+			entry.Status = "skipped"
+
+			return nil
 		}
+	}
+
+	// with --force files are uploaded anyway, but hashes are still updated
+	if hashes != nil && !force && !hashes.IsChanged(request.FileURI, request.File) {
+		fmt.Printf(
+			"%s (%s) not changed since last push\n",
+			strings.TrimPrefix(request.FileURI, branch),
+			request.FileType,
+		)
 
+		entry.Status = "not changed"
+
+		return nil
+	}
+
+	if dryRun {
 		fmt.Printf(
-			"%s (%s) %s [%d strings %d words]\n",
-			uri,
+			"%s -> %s (%s) [dry run]\n",
+			file,
+			request.FileURI,
 			request.FileType,
-			status,
-			response.StringCount,
-			response.WordCount,
+		)
+
+		entry.Status = "dry run"
+
+		return nil
+	}
+
+	response, err := client.UploadFile(project, *request)
+
+	if err != nil {
+		return NewError(
+			hierr.Errorf(
+				err,
+				`unable to upload file "%s"`,
+				file,
+			),
+
+			`Check, that you have enough permissions to upload file to`+
+				` the specified project`,
 		)
 	}
 
+	if hashes != nil {
+		err = hashes.Update(request.FileURI, request.File)
+		if err != nil {
+			return err
+		}
+	}
+
+	status := "new"
+	if response.Overwritten {
+		status = "overwritten"
+	}
+
+	entry.Status = status
+	entry.Pushed = true
+	entry.Strings = response.StringCount
+	entry.Words = response.WordCount
+
+	fmt.Printf(
+		"%s (%s) %s [%d strings %d words]\n",
+		strings.TrimPrefix(request.FileURI, branch),
+		request.FileType,
+		status,
+		response.StringCount,
+		response.WordCount,
+	)
+
+	if simulate != "" {
+		return writePseudoTranslation(client, config, args, request, simulate)
+	}
+
 	return nil
 }
+
+// writePushReport writes --report file, if it's requested, even if push
+// has failed. Push error takes precedence over error of writing report.
+func writePushReport(report *PushReport, failure error) error {
+	if report == nil {
+		return failure
+	}
+
+	err := report.Write()
+	if err != nil {
+		if failure != nil {
+			logger.Error(err)
+
+			return failure
+		}
+
+		return err
+	}
+
+	return failure
+}
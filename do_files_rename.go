@@ -1,6 +1,9 @@
 package main
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/Smartling/api-sdk-go"
 	"github.com/reconquest/hierr-go"
 )
@@ -11,11 +14,82 @@ func doFilesRename(
 	args map[string]interface{},
 ) error {
 	var (
-		project = config.ProjectID
-		oldURI  = args["<old-uri>"].(string)
-		newURI  = args["<new-uri>"].(string)
+		project   = config.ProjectID
+		oldURI    = args["<old-uri>"].(string)
+		newURI    = args["<new-uri>"].(string)
+		prefix, _ = args["--prefix"].(bool)
 	)
 
+	files, err := globFilesRemote(client, project, "")
+	if err != nil {
+		return err
+	}
+
+	if !prefix {
+		for _, file := range files {
+			if file.FileURI == oldURI {
+				return renameFile(client, project, oldURI, newURI)
+			}
+		}
+
+		return NewError(
+			fmt.Errorf(`file "%s" is not found in project`, oldURI),
+
+			`Check, that file URI is correct using "files list" command.`,
+		)
+	}
+
+	var renamed, failed int
+
+	for _, file := range files {
+		if !strings.HasPrefix(file.FileURI, oldURI) {
+			continue
+		}
+
+		err := renameFile(
+			client,
+			project,
+			file.FileURI,
+			newURI+strings.TrimPrefix(file.FileURI, oldURI),
+		)
+		if err != nil {
+			logger.Error(err)
+
+			failed++
+		} else {
+			renamed++
+		}
+	}
+
+	if renamed == 0 && failed == 0 {
+		return NewError(
+			fmt.Errorf(`no files found with prefix "%s"`, oldURI),
+
+			`Check, that prefix is correct using "files list" command.`,
+		)
+	}
+
+	if failed > 0 {
+		return NewError(
+			fmt.Errorf(
+				`%d of %d files were not renamed`,
+				failed,
+				renamed+failed,
+			),
+
+			`Check errors above and rerun command to rename remaining files.`,
+		)
+	}
+
+	return nil
+}
+
+func renameFile(
+	client *smartling.Client,
+	project string,
+	oldURI string,
+	newURI string,
+) error {
 	err := client.RenameFile(project, oldURI, newURI)
 	if err != nil {
 		return hierr.Errorf(
@@ -26,5 +100,7 @@ func doFilesRename(
 		)
 	}
 
+	fmt.Printf("%s renamed to %s\n", oldURI, newURI)
+
 	return nil
 }
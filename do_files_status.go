@@ -4,20 +4,71 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"text/tabwriter"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/Smartling/api-sdk-go"
+	"github.com/reconquest/hierr-go"
 )
 
+type FileStatusRow struct {
+	File   string `json:"file"`
+	Path   string `json:"path"`
+	Locale string `json:"locale"`
+	State  string `json:"state"`
+
+	AwaitingAuthorization int `json:"awaiting_authorization"`
+	InProgress            int `json:"in_progress"`
+	Completed             int `json:"completed"`
+
+	Progress string `json:"-"`
+	Words    int    `json:"-"`
+}
+
 func doFilesStatus(
 	client *smartling.Client,
 	config Config,
 	args map[string]interface{},
+) error {
+	var (
+		watch, _         = args["--watch"].(bool)
+		untilComplete, _ = args["--watch-until-complete"].(bool)
+	)
+
+	if watch || untilComplete {
+		return watchFilesStatus(client, config, args)
+	}
+
+	return showFilesStatus(client, config, args, map[string]*LocaleStats{})
+}
+
+// showFilesStatus writes status of every file and locale and aggregates
+// completion of every locale into given map.
+func showFilesStatus(
+	client *smartling.Client,
+	config Config,
+	args map[string]interface{},
+	completion map[string]*LocaleStats,
 ) error {
 	var (
 		project   = config.ProjectID
 		uri, _    = args["<uri>"].(string)
 		directory = args["--directory"].(string)
+		output, _ = args["--output"].(string)
+		since, _  = args["--since"].(string)
+
+		failBelow, _ = args["--fail-below"].(string)
+		summary, _   = args["--summary"].(bool)
+		order, _     = args["--sort"].(string)
+
+		onlyIncomplete, _ = args["--only-incomplete"].(bool)
+		groupsFile, _     = args["--locale-groups"].(string)
+		export, _         = args["--export"].(string)
+
+		excludes, _ = args["--exclude"].([]string)
+		locales, _  = args["--locale"].([]string)
 
 		defaultFormat, _ = args["--format"].(string)
 	)
@@ -26,6 +77,109 @@ func doFilesStatus(
 		defaultFormat = defaultFileStatusFormat
 	}
 
+	var groups map[string]string
+
+	if groupsFile != "" {
+		var err error
+
+		groups, err = loadLocaleGroupsFile(groupsFile)
+		if err != nil {
+			return NewError(
+				err,
+
+				`Locale groups file should contain JSON object with group `+
+					`names as keys and lists of locale IDs as values.`,
+			)
+		}
+
+		// groups are displayed only in summary
+		summary = true
+	}
+
+	if summary && export == "" && output != "" && output != "table" {
+		return NewError(
+			fmt.Errorf(`--summary can be displayed only as table`),
+
+			`Remove --output option or use it without --summary.`,
+		)
+	}
+
+	switch order {
+	case "", "completion", "locale":
+		// valid
+
+	default:
+		return NewError(
+			fmt.Errorf(`unknown sort order: %q`, order),
+
+			`Sort order should be either "completion" or "locale".`,
+		)
+	}
+
+	if output == "" {
+		output = "table"
+	}
+
+	target := os.Stdout
+
+	// with --export, rows are written into file in --output format, and
+	// summary is displayed instead
+	if export != "" {
+		file, err := os.Create(export)
+		if err != nil {
+			return NewError(
+				hierr.Errorf(err, `unable to create export file "%s"`, export),
+
+				`Check, that directory exists and you have permissions to `+
+					`write into it.`,
+			)
+		}
+
+		defer file.Close()
+
+		target = file
+	}
+
+	writer, err := getFileStatusWriter(output, target)
+	if err != nil {
+		return err
+	}
+
+	var threshold float64
+
+	if failBelow != "" {
+		threshold, err = strconv.ParseFloat(failBelow, 64)
+		if err != nil || threshold < 0 || threshold > 100 {
+			return NewError(
+				fmt.Errorf(`invalid --fail-below value: %q`, failBelow),
+
+				`Value should be percent number from 0 to 100, like 95.`,
+			)
+		}
+	}
+
+	var sinceTime time.Time
+
+	if since != "" {
+		sinceTime, err = time.Parse("2006-01-02", since)
+		if err != nil {
+			return NewError(
+				hierr.Errorf(err, `unable to parse --since date: %q`, since),
+
+				`Date should be specified in YYYY-MM-DD format.`,
+			)
+		}
+	}
+
+	if len(locales) > 0 {
+		locales = splitLocales(locales)
+
+		err = checkLocales(client, config, locales)
+		if err != nil {
+			return err
+		}
+	}
+
 	info, err := client.GetProjectDetails(project)
 	if err != nil {
 		return err
@@ -36,23 +190,91 @@ func doFilesStatus(
 		return err
 	}
 
-	var table = NewTableWriter(os.Stdout)
+	files, err = excludeFilesRemote(files, excludes)
+	if err != nil {
+		return err
+	}
+
+	var (
+		progress = Progress{
+			Total: len(files),
+		}
+
+		// every thread writes only own item, so no locking is required
+		statuses = make([]*smartling.FileStatus, len(files))
+		failures = make([]error, len(files))
+	)
+
+	pool := NewThreadPool(config.Threads)
+
+	for index, file := range files {
+		// func closure required to pass different file objects to goroutines
+		func(index int, file smartling.File) {
+			pool.Do(func() {
+				defer func() {
+					progress.Increment()
+					progress.Flush()
+				}()
 
-	var progress = Progress{
-		Total: len(files),
+				if since != "" {
+					changed, err := isFileChangedSince(
+						client,
+						project,
+						file,
+						sinceTime,
+					)
+					if err != nil {
+						failures[index] = err
+						return
+					}
+
+					if !changed {
+						logger.Infof(
+							"%s is not changed since %s",
+							file.FileURI,
+							since,
+						)
+
+						return
+					}
+				}
+
+				statuses[index], failures[index] = client.GetFileStatus(
+					project,
+					file.FileURI,
+				)
+			})
+		}(index, file)
 	}
 
-	for _, file := range files {
-		status, err := client.GetFileStatus(project, file.FileURI)
-		if err != nil {
-			return err
+	pool.Wait()
+
+	for index, file := range files {
+		if failures[index] != nil {
+			return failures[index]
 		}
 
-		progress.Increment()
-		progress.Flush()
+		status := statuses[index]
+		if status == nil {
+			continue
+		}
 
 		translations := status.Items
 
+		// file rows, including source row, are hidden only when every
+		// listed locale is complete
+		fileComplete := true
+
+		for _, translation := range translations {
+			if len(locales) > 0 && !hasLocaleInList(translation.LocaleID, locales) {
+				continue
+			}
+
+			if translation.CompletedStringCount < status.TotalStringCount {
+				fileComplete = false
+			}
+		}
+
 		translations = append(
 			[]smartling.FileStatusTranslation{
 				{
@@ -64,6 +286,12 @@ func doFilesStatus(
 		)
 
 		for _, translation := range translations {
+			if translation.LocaleID != "" && len(locales) > 0 {
+				if !hasLocaleInList(translation.LocaleID, locales) {
+					continue
+				}
+			}
+
 			path, err := executeFileFormat(
 				config,
 				file,
@@ -71,7 +299,7 @@ func doFilesStatus(
 				usePullFormat,
 				map[string]interface{}{
 					"FileURI": file.FileURI,
-					"Locale":  translation.LocaleID,
+					"Locale":  config.MapLocale(translation.LocaleID),
 				},
 			)
 			if err != nil {
@@ -80,17 +308,36 @@ func doFilesStatus(
 
 			path = filepath.Join(directory, path)
 
-			var (
-				locale   = info.SourceLocaleID
-				state    = "source"
-				progress = "source"
-			)
+			row := FileStatusRow{
+				File:      file.FileURI,
+				Path:      path,
+				Locale:    info.SourceLocaleID,
+				State:     "source",
+				Progress:  "source",
+				Completed: translation.CompletedStringCount,
+				Words:     translation.CompletedWordCount,
+			}
 
 			if translation.LocaleID != "" {
-				locale = translation.LocaleID
-				state = "remote"
+				row.Locale = translation.LocaleID
+				row.State = "remote"
+				row.InProgress = translation.AuthorizedStringCount
+
+				locale, ok := completion[translation.LocaleID]
+				if !ok {
+					locale = &LocaleStats{Locale: translation.LocaleID}
+					completion[translation.LocaleID] = locale
+				}
+
+				locale.Completed += translation.CompletedStringCount
+				locale.Total += status.TotalStringCount
+				row.AwaitingAuthorization = getAwaitingAuthorizationCount(
+					status,
+					translation,
+				)
+
 				if status.TotalStringCount > 0 {
-					progress = fmt.Sprintf(
+					row.Progress = fmt.Sprintf(
 						"%d%%",
 						int(
 							100*
@@ -99,42 +346,102 @@ func doFilesStatus(
 						),
 					)
 				} else {
-					progress = "-"
+					row.Progress = "-"
 				}
 			}
 
 			if !isFileExists(path) {
-				state = "missing"
+				row.State = "missing"
 			}
 
-			writeFileStatus(table, map[string]string{
-				"Path":     path,
-				"Locale":   locale,
-				"State":    state,
-				"Progress": progress,
-				"Strings":  fmt.Sprint(translation.CompletedStringCount),
-				"Words":    fmt.Sprint(translation.CompletedWordCount),
-			})
+			if summary && export == "" {
+				continue
+			}
+
+			if onlyIncomplete {
+				if fileComplete {
+					continue
+				}
+
+				if translation.LocaleID != "" &&
+					translation.CompletedStringCount >= status.TotalStringCount {
+					continue
+				}
+			}
+
+			err = writer.Write(row)
+			if err != nil {
+				return hierr.Errorf(
+					err,
+					"unable to write files status",
+				)
+			}
 		}
 	}
 
-	err = RenderTable(table)
+	err = writer.Flush()
 	if err != nil {
 		return err
 	}
 
-	return nil
-}
+	if summary || export != "" {
+		stats := []LocaleStats{}
+		for _, locale := range groupLocaleStats(completion, groups) {
+			if onlyIncomplete && locale.Completed >= locale.Total {
+				continue
+			}
+
+			stats = append(stats, locale)
+		}
+
+		if order == "locale" {
+			sort.Slice(stats, func(i, j int) bool {
+				return stats[i].Locale < stats[j].Locale
+			})
+		} else {
+			sort.Slice(stats, func(i, j int) bool {
+				if stats[i].Completion() == stats[j].Completion() {
+					return stats[i].Locale < stats[j].Locale
+				}
+
+				return stats[i].Completion() > stats[j].Completion()
+			})
+		}
+
+		err = renderLocaleStats(stats)
+		if err != nil {
+			return err
+		}
+	}
+
+	if failBelow == "" {
+		return nil
+	}
+
+	var incomplete []string
+
+	for _, locale := range completion {
+		if locale.Completion() < threshold {
+			incomplete = append(
+				incomplete,
+				fmt.Sprintf("%s (%d%%)", locale.Locale, int(locale.Completion())),
+			)
+		}
+	}
+
+	if len(incomplete) == 0 {
+		return nil
+	}
+
+	sort.Strings(incomplete)
+
+	return NewError(
+		fmt.Errorf(
+			"following locales are translated less than %s%%:\n\n  %s",
+			failBelow,
+			strings.Join(incomplete, "\n  "),
+		),
 
-func writeFileStatus(table *tabwriter.Writer, row map[string]string) {
-	fmt.Fprintf(
-		table,
-		"%s\t%s\t%s\t%s\t%s\t%s\n",
-		row["Path"],
-		row["Locale"],
-		row["State"],
-		row["Progress"],
-		row["Strings"],
-		row["Words"],
+		`Translations should be completed before proceeding.`,
 	)
 }
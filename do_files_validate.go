@@ -0,0 +1,146 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/Smartling/api-sdk-go"
+)
+
+func doFilesValidate(
+	client *smartling.Client,
+	config Config,
+	args map[string]interface{},
+) error {
+	var (
+		project     = config.ProjectID
+		file, _     = args["<file>"].(string)
+		directory   = args["--directory"].(string)
+		excludes, _ = args["--exclude"].([]string)
+	)
+
+	table := NewTableWriter(os.Stdout)
+
+	failures := 0
+
+	check := func(subject string, err error) bool {
+		if err == nil {
+			fmt.Fprintf(table, "ok\t%s\n", subject)
+
+			return true
+		}
+
+		if err, ok := err.(Error); ok {
+			fmt.Fprintf(table, "FAIL\t%s\t%s\n", subject, err.Cause)
+		} else {
+			fmt.Fprintf(table, "FAIL\t%s\t%s\n", subject, err)
+		}
+
+		failures++
+
+		return false
+	}
+
+	// client is already authenticated at this point
+	check("credentials", nil)
+
+	_, err := client.GetProjectDetails(project)
+	if _, ok := err.(smartling.NotFoundError); ok {
+		err = fmt.Errorf(`specified project is not found`)
+	}
+
+	check(fmt.Sprintf("project %s", project), err)
+
+	// formats are checked in stable order to keep output reproducible
+	keys := []string{}
+	for key := range config.Files {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		format := config.Files[key].Pull.Format
+		if format == "" {
+			continue
+		}
+
+		_, err := compileFormat(format)
+
+		check(fmt.Sprintf("pull format for %q", key), err)
+	}
+
+	patterns := []string{}
+
+	if file != "" {
+		patterns = append(patterns, file)
+	} else {
+		for _, key := range keys {
+			if config.Files[key].Push.Type != "" {
+				patterns = append(patterns, key)
+			}
+		}
+	}
+
+	// patterns from config file are relative to config file location
+	root := directory
+	if file == "" && !filepath.IsAbs(directory) {
+		root = filepath.Join(filepath.Dir(config.path), directory)
+	}
+
+	base, err := filepath.Abs(config.path)
+	if err != nil {
+		return err
+	}
+
+	base = filepath.Dir(base)
+
+	for _, mask := range patterns {
+		prefix, pattern := getDirectoryFromPattern(mask)
+
+		files, err := globFilesLocally(root, prefix, pattern)
+		if err == nil {
+			files, err = ignoreFilesLocally(files, base, config.ignore)
+		}
+
+		if err == nil {
+			files, err = excludeFilesLocally(files, base, excludes)
+		}
+
+		if err == nil && len(files) == 0 {
+			err = fmt.Errorf(`no files found`)
+		}
+
+		if !check(fmt.Sprintf("pattern %q", mask), err) {
+			continue
+		}
+
+		for _, path := range files {
+			request, err := buildUploadRequest(config, args, base, path)
+
+			subject := path
+			if err == nil {
+				subject = fmt.Sprintf("%s (%s)", path, request.FileType)
+			}
+
+			check(subject, err)
+		}
+	}
+
+	err = RenderTable(table)
+	if err != nil {
+		return err
+	}
+
+	if failures > 0 {
+		return NewError(
+			fmt.Errorf(`%d checks failed`, failures),
+
+			`Fix problems listed above and try again.`,
+		)
+	}
+
+	return nil
+}
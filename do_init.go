@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"regexp"
+	"strings"
 
 	"github.com/Smartling/api-sdk-go"
 	"github.com/reconquest/hierr-go"
@@ -14,8 +16,41 @@ import (
 )
 
 func doInit(config Config, args map[string]interface{}) error {
+	var (
+		nonInteractive = args["--non-interactive"].(bool)
+		mask, _        = args["<file>"].(string)
+		fileType, _    = args["--type"].(string)
+		format, _      = args["--format"].(string)
+	)
+
+	if nonInteractive {
+		if config.UserID == "" {
+			return MissingConfigValueError{
+				ConfigPath: config.path,
+				EnvVarName: "SMARTLING_USER_ID",
+				ValueName:  "user ID",
+				OptionName: "user",
+				KeyName:    "user_id",
+			}
+		}
+
+		if config.Secret == "" {
+			return MissingConfigValueError{
+				ConfigPath: config.path,
+				EnvVarName: "SMARTLING_SECRET",
+				ValueName:  "token secret",
+				OptionName: "secret",
+				KeyName:    "secret",
+			}
+		}
+	}
+
 	fmt.Printf("Generating %s...\n\n", config.path)
 
+	// single UI is used for all questions, so answers, which are piped into
+	// stdin, are not lost in buffers of separate readers
+	ui := input.DefaultUI()
+
 	prompt := func(
 		message string,
 		value interface{},
@@ -23,6 +58,10 @@ func doInit(config Config, args map[string]interface{}) error {
 		hidden bool,
 		variable interface{},
 	) {
+		if nonInteractive {
+			return
+		}
+
 		display := regexp.MustCompile(`^(.{1,3}).*$`).ReplaceAllString(
 			fmt.Sprint(value),
 			`$1***`,
@@ -32,7 +71,7 @@ func doInit(config Config, args map[string]interface{}) error {
 			message = fmt.Sprintf("%s [default is %q]", message, display)
 		}
 
-		read, err := input.DefaultUI().Ask(
+		read, err := ui.Ask(
 			message,
 			&input.Options{
 				Default:     fmt.Sprint(value),
@@ -46,7 +85,11 @@ func doInit(config Config, args map[string]interface{}) error {
 			}
 		}
 
-		fmt.Sscanln(read, variable)
+		if target, ok := variable.(*string); ok {
+			*target = strings.TrimSpace(read)
+		} else {
+			fmt.Sscanln(read, variable)
+		}
 	}
 
 	var input Config
@@ -99,8 +142,84 @@ func doInit(config Config, args map[string]interface{}) error {
 		config.ProjectID = input.ProjectID
 	}
 
+	var file struct {
+		Mask   string
+		Type   string
+		Format string
+	}
+
+	prompt(
+		"File mask to push (optional)",
+		mask,
+		mask == "",
+		false,
+		&file.Mask,
+	)
+
+	if file.Mask == "" {
+		file.Mask = mask
+	}
+
+	if file.Mask != "" {
+		if fileType == "" {
+			fileType = string(
+				smartling.GetFileTypeByExtension(filepath.Ext(file.Mask)),
+			)
+		}
+
+		prompt(
+			"File type for files matching mask (optional)",
+			fileType,
+			fileType == "",
+			false,
+			&file.Type,
+		)
+
+		if file.Type == "" {
+			file.Type = fileType
+		}
+
+		if file.Type == "" {
+			return NewError(
+				fmt.Errorf(
+					"unable to deduce file type from extension: %q",
+					filepath.Ext(file.Mask),
+				),
+
+				`You need to specify file type via --type option.`,
+			)
+		}
+	}
+
+	if format == "" {
+		format = defaultFilePullFormat
+	}
+
+	prompt(
+		"Pull file name format (optional, empty for default)",
+		format,
+		true,
+		false,
+		&file.Format,
+	)
+
+	if file.Format == "" {
+		file.Format = format
+	}
+
+	_, err := compileFormat(file.Format)
+	if err != nil {
+		return err
+	}
+
 	var result bytes.Buffer
-	err := configTemplate.Execute(&result, config)
+	err = configTemplate.Execute(&result, struct {
+		Config
+		File interface{}
+	}{
+		config,
+		file,
+	})
 	if err != nil {
 		return hierr.Errorf(
 			err,
@@ -136,6 +255,20 @@ func doInit(config Config, args map[string]interface{}) error {
 		}
 	}
 
+	if config.ProjectID != "" {
+		_, err = client.GetProjectDetails(config.ProjectID)
+		if err != nil {
+			if _, ok := err.(smartling.NotFoundError); ok {
+				return ProjectNotFoundError{}
+			}
+
+			return NewError(
+				hierr.Errorf(err, "failure while testing connection"),
+				"Contact developer for more info.",
+			)
+		}
+	}
+
 	fmt.Println("Connection is successfull.")
 
 	if args["--dry-run"].(bool) {
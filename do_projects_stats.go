@@ -0,0 +1,149 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/Smartling/api-sdk-go"
+	"github.com/reconquest/hierr-go"
+)
+
+type LocaleStats struct {
+	Locale    string
+	Completed int
+	Total     int
+}
+
+func (stats LocaleStats) Completion() float64 {
+	if stats.Total == 0 {
+		return 100
+	}
+
+	return 100 * float64(stats.Completed) / float64(stats.Total)
+}
+
+func doProjectsStats(
+	client *smartling.Client,
+	config Config,
+	args map[string]interface{},
+) error {
+	var (
+		project          = config.ProjectID
+		order, _         = args["--sort"].(string)
+		minCompletion, _ = args["--min-completion"].(string)
+	)
+
+	var threshold float64
+
+	if minCompletion != "" {
+		var err error
+
+		threshold, err = strconv.ParseFloat(minCompletion, 64)
+		if err != nil {
+			return NewError(
+				hierr.Errorf(
+					err,
+					`unable to parse --min-completion value: %q`,
+					minCompletion,
+				),
+
+				`Value should be percent number, like 95.`,
+			)
+		}
+	}
+
+	stats, err := getProjectStats(client, project)
+	if err != nil {
+		return err
+	}
+
+	switch order {
+	case "", "locale":
+		sort.Slice(stats, func(i, j int) bool {
+			return stats[i].Locale < stats[j].Locale
+		})
+
+	case "completion":
+		sort.SliceStable(stats, func(i, j int) bool {
+			return stats[i].Completion() < stats[j].Completion()
+		})
+
+	default:
+		return NewError(
+			fmt.Errorf(`unknown sort order: %q`, order),
+
+			`Sort order should be either "locale" or "completion".`,
+		)
+	}
+
+	err = renderLocaleStats(stats)
+	if err != nil {
+		return err
+	}
+
+	if minCompletion == "" {
+		return nil
+	}
+
+	for _, locale := range stats {
+		if locale.Completion() < threshold {
+			return NewError(
+				fmt.Errorf(
+					`locale "%s" is only %d%% complete, which is below %s%%`,
+					locale.Locale,
+					int(locale.Completion()),
+					minCompletion,
+				),
+
+				`Translations should be completed before proceeding.`,
+			)
+		}
+	}
+
+	return nil
+}
+
+func getProjectStats(
+	client *smartling.Client,
+	project string,
+) ([]LocaleStats, error) {
+	files, err := globFilesRemote(client, project, "")
+	if err != nil {
+		return nil, err
+	}
+
+	locales := map[string]*LocaleStats{}
+
+	for _, file := range files {
+		status, err := client.GetFileStatus(project, file.FileURI)
+		if err != nil {
+			return nil, hierr.Errorf(
+				err,
+				`unable to get file "%s" status`,
+				file.FileURI,
+			)
+		}
+
+		for _, translation := range status.Items {
+			locale, ok := locales[translation.LocaleID]
+			if !ok {
+				locale = &LocaleStats{
+					Locale: translation.LocaleID,
+				}
+
+				locales[translation.LocaleID] = locale
+			}
+
+			locale.Completed += translation.CompletedStringCount
+			locale.Total += status.TotalStringCount
+		}
+	}
+
+	stats := []LocaleStats{}
+	for _, locale := range locales {
+		stats = append(stats, *locale)
+	}
+
+	return stats, nil
+}
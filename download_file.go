@@ -1,7 +1,10 @@
 package main
 
 import (
+	"bytes"
+	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 
@@ -16,7 +19,13 @@ func downloadFile(
 	locale string,
 	path string,
 	retrievalType smartling.RetrievalType,
-) error {
+	checksum bool,
+	skipUnchanged bool,
+	manifest *FileHashes,
+	pending *PendingWrites,
+	encoding *OutputEncoding,
+	source []byte,
+) (bool, error) {
 	var (
 		reader io.Reader
 		err    error
@@ -25,7 +34,7 @@ func downloadFile(
 	if locale == "" {
 		reader, err = client.DownloadFile(project, file.FileURI)
 		if err != nil {
-			return hierr.Errorf(
+			return false, hierr.Errorf(
 				err,
 				`unable to download original file "%s" from project "%s"`,
 				file.FileURI,
@@ -38,8 +47,12 @@ func downloadFile(
 		request.Type = retrievalType
 
 		reader, err = client.DownloadTranslation(project, locale, request)
+		if _, ok := err.(smartling.NotFoundError); ok {
+			return false, missingFileError{URI: file.FileURI, Locale: locale}
+		}
+
 		if err != nil {
-			return hierr.Errorf(
+			return false, hierr.Errorf(
 				err,
 				`unable to download file "%s" from project "%s" (locale "%s")`,
 				file.FileURI,
@@ -49,34 +62,160 @@ func downloadFile(
 		}
 	}
 
+	// translations are streamed to temporary file next to target one, so
+	// memory usage does not grow with file size and number of parallel
+	// downloads; merge with source and conversion require whole file, though
+	if source != nil || encoding != nil {
+		data, err := ioutil.ReadAll(reader)
+		if err != nil {
+			return false, hierr.Errorf(
+				err,
+				`unable to read downloaded file "%s" contents`,
+				file.FileURI,
+			)
+		}
+
+		if source != nil {
+			data, err = mergeWithSource(file.FileType, data, source)
+			if err != nil {
+				return false, hierr.Errorf(
+					err,
+					`unable to merge translation of "%s" with source file`,
+					file.FileURI,
+				)
+			}
+		}
+
+		if encoding != nil {
+			data, err = encoding.Encode(data)
+			if err != nil {
+				return false, hierr.Errorf(
+					err,
+					`unable to convert "%s" into %s encoding`,
+					file.FileURI,
+					encoding.Name,
+				)
+			}
+		}
+
+		reader = bytes.NewReader(data)
+	}
+
 	err = os.MkdirAll(filepath.Dir(path), 0755)
 	if err != nil {
-		return hierr.Errorf(
+		return false, hierr.Errorf(
 			err,
 			`unable to create dirs hierarchy "%s" for downloaded file`,
 			path,
 		)
 	}
 
-	writer, err := os.Create(path)
+	temp, sum, err := writeTempFile(path, reader)
 	if err != nil {
-		return hierr.Errorf(
+		return false, hierr.Errorf(
 			err,
-			`unable to create output file "%s"`,
-			path,
+			`unable to write downloaded file "%s" contents`,
+			file.FileURI,
 		)
 	}
 
-	defer writer.Close()
+	if checksum {
+		// file is not rewritten if it's not changed to not trigger
+		// file watchers
+		previous := ""
+		if manifest != nil {
+			previous = manifest.Get(path)
+		} else {
+			previous, err = readChecksum(path)
+			if err != nil {
+				os.Remove(temp)
+
+				return false, err
+			}
+		}
+
+		if previous == sum && isFileExists(path) {
+			logger.Infof("%s is not changed, skipping", path)
+
+			os.Remove(temp)
+
+			return false, nil
+		}
+	}
+
+	// unlike --checksum, local file itself is compared, so it works without
+	// any state from previous pull
+	if skipUnchanged && !checksum {
+		previous, err := ioutil.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			os.Remove(temp)
+
+			return false, hierr.Errorf(
+				err,
+				`unable to read existing file "%s"`,
+				path,
+			)
+		}
 
-	_, err = io.Copy(writer, reader)
+		if err == nil && computeChecksum(previous) == sum {
+			logger.Infof("%s is not changed, skipping", path)
+
+			os.Remove(temp)
+
+			return false, nil
+		}
+	}
+
+	commit := func() error {
+		err := os.Rename(temp, path)
+		if err != nil {
+			os.Remove(temp)
+
+			return hierr.Errorf(
+				err,
+				`unable to write file contents into "%s"`,
+				path,
+			)
+		}
+
+		switch {
+		case checksum && manifest != nil:
+			return manifest.Set(path, sum)
+
+		case checksum:
+			return writeChecksum(path, sum)
+		}
+
+		return nil
+	}
+
+	// with --atomic, files are moved into place only after all downloads
+	// are succeeded
+	if pending != nil {
+		pending.Add(temp, commit)
+
+		return true, nil
+	}
+
+	err = commit()
 	if err != nil {
-		return hierr.Errorf(
-			err,
-			`unable to write file contents into "%s"`,
-			path,
-		)
+		return false, err
 	}
 
-	return nil
+	return true, nil
+}
+
+// missingFileError is returned when translation of file is not found in
+// project, which is handled according to --on-missing-file option.
+type missingFileError struct {
+	URI    string
+	Locale string
+}
+
+func (err missingFileError) Error() string {
+	return fmt.Sprintf(
+		`translation of file "%s" into locale "%s" is not found in project`,
+		err.URI,
+		err.Locale,
+	)
 }
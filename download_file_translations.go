@@ -2,20 +2,38 @@ package main
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Smartling/api-sdk-go"
 	"github.com/reconquest/hierr-go"
 )
 
-func downloadFileTranslations(
+// fileDownload is single file written by pull: translation of source file
+// into locale or source file itself.
+type fileDownload struct {
+	locale   string
+	path     string
+	complete int64
+
+	// original is set for source file written by --include-original
+	original bool
+}
+
+// getFileDownloads returns files, which should be written for translations
+// of given source file, so total count of files is known before anything
+// is downloaded.
+func getFileDownloads(
 	client *smartling.Client,
 	config Config,
 	args map[string]interface{},
+	options PullOptions,
 	file smartling.File,
-) error {
+) ([]fileDownload, error) {
 	var (
 		project   = config.ProjectID
 		directory = args["--directory"].(string)
@@ -24,7 +42,15 @@ func downloadFileTranslations(
 
 		format, formatGiven = args["--format"].(string)
 		progress, _         = args["--progress"].(string)
-		retrieve, _         = args["--retrieve"].(string)
+		verify, _           = args["--verify"].(bool)
+		missingOnly, _      = args["--missing-only"].(bool)
+		overwriteEmpty, _   = args["--overwrite-empty"].(bool)
+		ifNewer, _          = args["--if-newer"].(bool)
+		sincePush, _        = args["--since-push"].(bool)
+
+		sourceLocale = options.SourceLocale
+		localeFilter = options.LocaleFilter
+		fileMap      = options.LocaleFileMap
 	)
 
 	progress = strings.TrimSuffix(progress, "%")
@@ -34,21 +60,19 @@ func downloadFileTranslations(
 
 	percents, err := strconv.ParseInt(progress, 10, 0)
 	if err != nil {
-		return hierr.Errorf(
+		return nil, hierr.Errorf(
 			err,
 			"unable to parse --progress as integer",
 		)
 	}
 
-	retrievalType := smartling.RetrievalType(retrieve)
-
 	if format == "" {
-		format = defaultFileStatusFormat
+		format = defaultFilePullFormat
 	}
 
 	status, err := client.GetFileStatus(project, file.FileURI)
 	if err != nil {
-		return hierr.Errorf(
+		return nil, hierr.Errorf(
 			err,
 			`unable to retrieve file "%s" locales from project "%s"`,
 			file.FileURI,
@@ -66,6 +90,58 @@ func downloadFileTranslations(
 		translations = status.Items
 	}
 
+	var (
+		downloads []fileDownload
+		modified  map[string]time.Time
+	)
+
+	useFormat := usePullFormat
+	if formatGiven {
+		useFormat = func(FileConfig) string {
+			return format
+		}
+	}
+
+	fileConfig, err := config.GetFileConfig(file.FileURI)
+	if err != nil {
+		return nil, err
+	}
+
+	// files which are already bootstrapped are never overwritten, while
+	// empty and missing ones are downloaded
+	skipNonEmpty := func(path string) (bool, error) {
+		if verify || !(overwriteEmpty || fileConfig.Pull.OverwriteEmpty) {
+			return false, nil
+		}
+
+		if !isFileExists(path) {
+			return false, nil
+		}
+
+		empty, err := isEmptyLocaleFile(path, file.FileType)
+		if err != nil {
+			return false, err
+		}
+
+		if !empty {
+			logger.Infof("%s is not empty, skipping", path)
+		}
+
+		return !empty, nil
+	}
+
+	// formats from --locale-file-map take precedence over both --format
+	// and pull.format from config file
+	getFormat := func(locale string) (string, func(FileConfig) string) {
+		if template, ok := fileMap[locale]; ok {
+			return template, func(FileConfig) string {
+				return template
+			}
+		}
+
+		return format, useFormat
+	}
+
 	for _, locale := range translations {
 		var complete int64
 
@@ -89,49 +165,308 @@ func downloadFileTranslations(
 			}
 		}
 
-		useFormat := usePullFormat
-		if formatGiven {
-			useFormat = func(FileConfig) string {
-				return format
+		if localeFilter != nil && locale.LocaleID != "" {
+			if !localeFilter.MatchString(locale.LocaleID) {
+				continue
 			}
 		}
 
+		localeFormat, useLocaleFormat := getFormat(locale.LocaleID)
+
 		path, err := executeFileFormat(
 			config,
 			file,
-			format,
-			useFormat,
+			localeFormat,
+			useLocaleFormat,
 			map[string]interface{}{
 				"FileURI": file.FileURI,
-				"Locale":  locale.LocaleID,
+				"Locale":  config.MapLocale(locale.LocaleID),
 			},
 		)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		path = filepath.Join(directory, path)
 
-		err = downloadFile(
+		if missingOnly && !verify && isFileExists(path) {
+			logger.Infof("%s already exists, skipping", path)
+
+			continue
+		}
+
+		skip, err := skipNonEmpty(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if skip {
+			continue
+		}
+
+		if ifNewer && !verify {
+			var updated time.Time
+
+			// source file has no translation modification time, so time
+			// of its last upload is used instead
+			if locale.LocaleID == "" {
+				updated = file.LastUploaded.Time
+			} else {
+				if modified == nil {
+					modified, err = getLastModified(client, project, file)
+					if err != nil {
+						return nil, err
+					}
+				}
+
+				updated = modified[locale.LocaleID]
+			}
+
+			local, err := os.Stat(path)
+			if err == nil && !updated.IsZero() && !updated.After(local.ModTime()) {
+				logger.Infof("%s is up to date, skipping", path)
+
+				continue
+			}
+		}
+
+		// source file is always downloaded, since it's pushed itself, and
+		// missing files are downloaded regardless of modification time
+		if sincePush && !verify && locale.LocaleID != "" && isFileExists(path) {
+			if modified == nil {
+				modified, err = getLastModified(client, project, file)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			if !modified[locale.LocaleID].After(file.LastUploaded.Time) {
+				logger.Infof("%s is not modified since last push, skipping", path)
+
+				continue
+			}
+		}
+
+		downloads = append(downloads, fileDownload{
+			locale:   locale.LocaleID,
+			path:     path,
+			complete: complete,
+		})
+	}
+
+	// source locale is set only by --include-original
+	if sourceLocale != "" && !source {
+		sourceFormat, useSourceFormat := getFormat(sourceLocale)
+
+		path, err := executeFileFormat(
+			config,
+			file,
+			sourceFormat,
+			useSourceFormat,
+			map[string]interface{}{
+				"FileURI": file.FileURI,
+				"Locale":  config.MapLocale(sourceLocale),
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		path = filepath.Join(directory, path)
+
+		skip, err := skipNonEmpty(path)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case missingOnly && !verify && isFileExists(path):
+			logger.Infof("%s already exists, skipping", path)
+
+		case skip:
+			// reported already
+
+		default:
+			downloads = append(downloads, fileDownload{
+				path:     path,
+				original: true,
+			})
+		}
+	}
+
+	return downloads, nil
+}
+
+// downloadFileTranslations downloads and writes given files for
+// translations of source file, reporting every written file to progress.
+func downloadFileTranslations(
+	client *smartling.Client,
+	config Config,
+	args map[string]interface{},
+	options PullOptions,
+	file smartling.File,
+	downloads []fileDownload,
+	progress *Progress,
+) error {
+	var (
+		project = config.ProjectID
+		source  = args["--source"].(bool)
+
+		retrieve, _      = args["--retrieve"].(string)
+		checksum, _      = args["--checksum"].(bool)
+		skipUnchanged, _ = args["--skip-unchanged"].(bool)
+		verify, _        = args["--verify"].(bool)
+		onMissing, _     = args["--on-missing-file"].(string)
+		merge, _         = args["--merge-with-source"].(bool)
+		postProcess, _   = args["--post-process"].(string)
+
+		manifest = options.Checksums
+		pending  = options.Pending
+		encoding = options.Encoding
+	)
+
+	retrievalType := smartling.RetrievalType(retrieve)
+
+	var original []byte
+
+	if merge && !source && !verify && len(downloads) > 0 {
+		if isMergeWithSourceSupported(file.FileType) {
+			reader, err := client.DownloadFile(project, file.FileURI)
+			if err != nil {
+				return hierr.Errorf(
+					err,
+					`unable to download original file "%s" to merge with`,
+					file.FileURI,
+				)
+			}
+
+			original, err = ioutil.ReadAll(reader)
+			if err != nil {
+				return hierr.Errorf(
+					err,
+					`unable to read original file "%s" contents`,
+					file.FileURI,
+				)
+			}
+		} else {
+			logger.Warningf(
+				"merge with source is not supported for %s files, "+
+					"downloading %s as is",
+				file.FileType,
+				file.FileURI,
+			)
+		}
+	}
+
+	var mismatched, failed []string
+
+	for _, download := range downloads {
+		if verify {
+			valid, err := verifyChecksum(download.path, manifest)
+			if err != nil {
+				return err
+			}
+
+			if valid {
+				fmt.Printf("verified %s\n", download.path)
+			} else {
+				fmt.Printf("mismatch %s\n", download.path)
+
+				mismatched = append(mismatched, download.path)
+			}
+
+			progress.Done(download.locale, download.path)
+
+			continue
+		}
+
+		mergeSource := original
+		if download.original {
+			mergeSource = nil
+		}
+
+		written, err := downloadFile(
 			client,
 			project,
 			file,
-			locale.LocaleID,
-			path,
+			download.locale,
+			download.path,
 			retrievalType,
+			checksum,
+			skipUnchanged,
+			manifest,
+			pending,
+			encoding,
+			mergeSource,
 		)
+		if _, ok := err.(missingFileError); ok && onMissing != "error" {
+			if onMissing == "create-empty" {
+				err = createEmptyFile(download.path, pending)
+				if err != nil {
+					return err
+				}
+
+				fmt.Printf("created empty %s\n", download.path)
+			} else {
+				logger.Warningf("%s, skipping", err)
+			}
+
+			progress.Done(download.locale, download.path)
+
+			continue
+		}
+
 		if err != nil {
 			return err
 		}
 
-		if source {
-			fmt.Printf("downloaded %s\n", path)
-		} else {
-			fmt.Printf("downloaded %s %d%%\n", path, int(complete))
+		if written && postProcess != "" {
+			err = runPostProcess(postProcess, download.path)
+			if err != nil {
+				logger.Error(err)
+
+				failed = append(failed, download.locale)
+
+				progress.Done(download.locale, download.path)
+
+				continue
+			}
 		}
+
+		switch {
+		case !written:
+			fmt.Printf("not changed %s\n", download.path)
+
+		case source:
+			fmt.Printf("downloaded %s\n", download.path)
+
+		case download.original:
+			fmt.Printf("downloaded original %s\n", download.path)
+
+		default:
+			fmt.Printf(
+				"downloaded %s %d%%\n",
+				download.path,
+				int(download.complete),
+			)
+		}
+
+		progress.Done(download.locale, download.path)
+	}
+
+	if len(mismatched) > 0 {
+		return checksumMismatchError{Paths: mismatched}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf(
+			`post-process command failed for "%s" (locales: %s)`,
+			file.FileURI,
+			strings.Join(failed, ", "),
+		)
 	}
 
-	return err
+	return nil
 }
 
 func hasLocaleInList(locale string, locales []string) bool {
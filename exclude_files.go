@@ -0,0 +1,97 @@
+package main
+
+import (
+	"path/filepath"
+
+	"github.com/Smartling/api-sdk-go"
+	"github.com/gobwas/glob"
+)
+
+func compileExcludes(masks []string) ([]glob.Glob, error) {
+	var patterns []glob.Glob
+
+	for _, mask := range masks {
+		pattern, err := glob.Compile(mask, '/')
+		if err != nil {
+			return nil, NewError(
+				err,
+				"Exclude pattern is malformed. Check out help for more "+
+					"information about search patterns.",
+			)
+		}
+
+		patterns = append(patterns, pattern)
+	}
+
+	return patterns, nil
+}
+
+func isExcluded(path string, patterns []glob.Glob) bool {
+	for _, pattern := range patterns {
+		if pattern.Match(path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func excludeFilesRemote(
+	files []smartling.File,
+	masks []string,
+) ([]smartling.File, error) {
+	patterns, err := compileExcludes(masks)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []smartling.File
+
+	for _, file := range files {
+		if isExcluded(file.FileURI, patterns) {
+			logger.Infof("excluding %s", file.FileURI)
+
+			continue
+		}
+
+		result = append(result, file)
+	}
+
+	return result, nil
+}
+
+// excludeFilesLocally matches masks against paths relative to given base
+// directory, so masks look the same as file URIs after push.
+func excludeFilesLocally(
+	files []string,
+	base string,
+	masks []string,
+) ([]string, error) {
+	patterns, err := compileExcludes(masks)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []string
+
+	for _, file := range files {
+		path, err := filepath.Abs(file)
+		if err == nil {
+			path, err = filepath.Rel(base, path)
+		}
+
+		if err != nil {
+			path = file
+		}
+
+		if isExcluded(filepath.ToSlash(path), patterns) {
+			logger.Infof("excluding %s", file)
+
+			continue
+		}
+
+		result = append(result, file)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+
+	"gopkg.in/yaml.v2"
+)
+
+var envPlaceholderRegexp = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// unmarshalConfig checks YAML document for unknown keys, expands ${NAME}
+// placeholders in every string value and only then unmarshals it into
+// target, so comments are not affected.
+func unmarshalConfig(data []byte, target interface{}) error {
+	var document interface{}
+
+	err := yaml.Unmarshal(data, &document)
+	if err != nil {
+		return err
+	}
+
+	err = checkUnknownKeys(document, reflect.TypeOf(target), "")
+	if err != nil {
+		return err
+	}
+
+	document, err = expandEnv(document)
+	if err != nil {
+		return err
+	}
+
+	data, err = yaml.Marshal(document)
+	if err != nil {
+		return err
+	}
+
+	return yaml.Unmarshal(data, target)
+}
+
+func expandEnv(value interface{}) (interface{}, error) {
+	switch value := value.(type) {
+	case string:
+		var missing []string
+
+		result := envPlaceholderRegexp.ReplaceAllStringFunc(
+			value,
+			func(placeholder string) string {
+				name := envPlaceholderRegexp.FindStringSubmatch(placeholder)[1]
+
+				env, ok := os.LookupEnv(name)
+				if !ok {
+					missing = append(missing, name)
+				}
+
+				return env
+			},
+		)
+
+		if len(missing) > 0 {
+			return nil, fmt.Errorf(
+				`environment variable "%s" is not set`,
+				missing[0],
+			)
+		}
+
+		if result != value {
+			return getExpandedScalar(result), nil
+		}
+
+		return result, nil
+
+	case map[interface{}]interface{}:
+		for key, item := range value {
+			expanded, err := expandEnv(item)
+			if err != nil {
+				return nil, err
+			}
+
+			value[key] = expanded
+		}
+
+	case []interface{}:
+		for index, item := range value {
+			expanded, err := expandEnv(item)
+			if err != nil {
+				return nil, err
+			}
+
+			value[index] = expanded
+		}
+	}
+
+	return value, nil
+}
+
+// getExpandedScalar keeps type of expanded value if it's number or boolean,
+// so "threads: ${THREADS}" can be unmarshalled into integer field. Value is
+// kept as string if its type can not be restored from text without
+// changes, e.g. "0123" would be read as octal number otherwise.
+func getExpandedScalar(value string) interface{} {
+	var typed interface{}
+
+	err := yaml.Unmarshal([]byte(value), &typed)
+	if err != nil {
+		return value
+	}
+
+	switch typed.(type) {
+	case int, int64, uint64, float64, bool:
+		data, err := yaml.Marshal(typed)
+		if err == nil && string(data) == value+"\n" {
+			return typed
+		}
+	}
+
+	return value
+}
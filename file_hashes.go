@@ -0,0 +1,181 @@
+package main
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/reconquest/hierr-go"
+)
+
+// fileHashesName is name of file, located alongside config file, where
+// hashes of pushed files are stored by push --smart-update.
+const fileHashesName = ".smartling-hashes"
+
+// defaultHashAlgorithm is used for hashes files without algorithm header,
+// which keeps them compatible with sha256sum tool.
+const defaultHashAlgorithm = "sha256"
+
+// fileHashesHeader prefixes first line of hashes file written with other
+// than default algorithm.
+const fileHashesHeader = "# algorithm: "
+
+// hashAlgorithms lists algorithms which can be used for hashes file.
+var hashAlgorithms = map[string]func() hash.Hash{
+	"sha256": sha256.New,
+	"sha1":   sha1.New,
+	"md5":    md5.New,
+	"xxhash": newXXHash64,
+}
+
+// FileHashes keeps hashes of files contents, which were pushed last time,
+// keyed by file URI. It's safe for concurrent use.
+type FileHashes struct {
+	sync.Mutex
+
+	path      string
+	algorithm string
+	hashes    map[string]string
+}
+
+// loadFileHashes reads hashes file, which is written in the same format as
+// sha256sum output, but with file URIs instead of paths. Missing file is
+// treated as empty one. If file was written with another algorithm than
+// specified one, its hashes are discarded, so all files are considered
+// changed.
+func loadFileHashes(path string, algorithm string) (*FileHashes, error) {
+	if _, ok := hashAlgorithms[algorithm]; !ok {
+		return nil, fmt.Errorf(`unknown hash algorithm: %q`, algorithm)
+	}
+
+	hashes := &FileHashes{
+		path:      path,
+		algorithm: algorithm,
+		hashes:    map[string]string{},
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return hashes, nil
+		}
+
+		return nil, hierr.Errorf(err, `unable to read hashes file "%s"`, path)
+	}
+
+	lines := strings.Split(string(data), "\n")
+
+	stored := defaultHashAlgorithm
+	if strings.HasPrefix(lines[0], fileHashesHeader) {
+		stored = strings.TrimPrefix(lines[0], fileHashesHeader)
+	}
+
+	if stored != algorithm {
+		logger.Infof(
+			"hashes file %q is written with %s instead of %s, ignoring it",
+			path,
+			stored,
+			algorithm,
+		)
+
+		return hashes, nil
+	}
+
+	for _, line := range lines {
+		fields := strings.SplitN(line, "  ", 2)
+		if len(fields) != 2 {
+			continue
+		}
+
+		hashes.hashes[fields[1]] = fields[0]
+	}
+
+	return hashes, nil
+}
+
+// IsChanged returns true if contents differ from contents pushed last time
+// under specified URI.
+func (hashes *FileHashes) IsChanged(uri string, contents []byte) bool {
+	hashes.Lock()
+	defer hashes.Unlock()
+
+	return hashes.hashes[uri] != hashes.compute(contents)
+}
+
+// Get returns hash stored for specified key or empty string if there is
+// none.
+func (hashes *FileHashes) Get(key string) string {
+	hashes.Lock()
+	defer hashes.Unlock()
+
+	return hashes.hashes[key]
+}
+
+// Update stores hash of pushed contents and writes hashes file right away,
+// so interrupted push does not lose already pushed files.
+func (hashes *FileHashes) Update(uri string, contents []byte) error {
+	return hashes.Set(uri, hashes.compute(contents))
+}
+
+// Set stores already computed hash under specified key and writes hashes
+// file right away through temporary file.
+func (hashes *FileHashes) Set(key string, hash string) error {
+	hashes.Lock()
+	defer hashes.Unlock()
+
+	hashes.hashes[key] = hash
+
+	uris := []string{}
+	for uri := range hashes.hashes {
+		uris = append(uris, uri)
+	}
+
+	sort.Strings(uris)
+
+	buffer := []string{}
+	if hashes.algorithm != defaultHashAlgorithm {
+		buffer = append(buffer, fileHashesHeader+hashes.algorithm+"\n")
+	}
+
+	for _, uri := range uris {
+		buffer = append(buffer, fmt.Sprintf("%s  %s\n", hashes.hashes[uri], uri))
+	}
+
+	// file is replaced atomically, so interrupted write never leaves
+	// truncated hashes file
+	temp, _, err := writeTempFile(
+		hashes.path,
+		strings.NewReader(strings.Join(buffer, "")),
+	)
+	if err == nil {
+		err = os.Rename(temp, hashes.path)
+		if err != nil {
+			os.Remove(temp)
+		}
+	}
+
+	if err != nil {
+		return hierr.Errorf(
+			err,
+			`unable to write hashes file "%s"`,
+			hashes.path,
+		)
+	}
+
+	return nil
+}
+
+func (hashes *FileHashes) compute(contents []byte) string {
+	hash := hashAlgorithms[hashes.algorithm]()
+	hash.Write(contents)
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
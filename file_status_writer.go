@@ -0,0 +1,156 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/reconquest/hierr-go"
+)
+
+// FileStatusWriter outputs rows of files status command. New output types
+// can be added by registering writer constructor in fileStatusWriters.
+type FileStatusWriter interface {
+	Write(row FileStatusRow) error
+	Flush() error
+}
+
+var fileStatusWriters = map[string]func(io.Writer) FileStatusWriter{
+	"table": NewFileStatusTableWriter,
+	"csv":   NewFileStatusCSVWriter,
+	"json":  NewFileStatusJSONWriter,
+}
+
+func getFileStatusWriter(
+	output string,
+	target io.Writer,
+) (FileStatusWriter, error) {
+	constructor, ok := fileStatusWriters[output]
+	if !ok {
+		var known []string
+
+		for name := range fileStatusWriters {
+			known = append(known, name)
+		}
+
+		sort.Strings(known)
+
+		return nil, NewError(
+			fmt.Errorf(`unknown output type: "%s"`, output),
+
+			`Output type should be one of: %s.`,
+			strings.Join(known, ", "),
+		)
+	}
+
+	return constructor(target), nil
+}
+
+type fileStatusTableWriter struct {
+	table *tabwriter.Writer
+}
+
+func NewFileStatusTableWriter(target io.Writer) FileStatusWriter {
+	return &fileStatusTableWriter{
+		table: NewTableWriter(target),
+	}
+}
+
+func (writer *fileStatusTableWriter) Write(row FileStatusRow) error {
+	_, err := fmt.Fprintf(
+		writer.table,
+		"%s\t%s\t%s\t%s\t%d\t%d\n",
+		row.Path,
+		row.Locale,
+		row.State,
+		row.Progress,
+		row.Completed,
+		row.Words,
+	)
+
+	return err
+}
+
+func (writer *fileStatusTableWriter) Flush() error {
+	return RenderTable(writer.table)
+}
+
+type fileStatusCSVWriter struct {
+	csv    *csv.Writer
+	header bool
+}
+
+func NewFileStatusCSVWriter(target io.Writer) FileStatusWriter {
+	return &fileStatusCSVWriter{
+		csv: csv.NewWriter(target),
+	}
+}
+
+func (writer *fileStatusCSVWriter) Write(row FileStatusRow) error {
+	if !writer.header {
+		writer.header = true
+
+		err := writer.csv.Write([]string{
+			"file",
+			"path",
+			"locale",
+			"state",
+			"awaiting_authorization",
+			"in_progress",
+			"completed",
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return writer.csv.Write([]string{
+		row.File,
+		row.Path,
+		row.Locale,
+		row.State,
+		fmt.Sprint(row.AwaitingAuthorization),
+		fmt.Sprint(row.InProgress),
+		fmt.Sprint(row.Completed),
+	})
+}
+
+func (writer *fileStatusCSVWriter) Flush() error {
+	writer.csv.Flush()
+
+	err := writer.csv.Error()
+	if err != nil {
+		return hierr.Errorf(
+			err,
+			"unable to write files status as CSV",
+		)
+	}
+
+	return nil
+}
+
+// fileStatusJSONWriter collects all rows and writes them as single array.
+type fileStatusJSONWriter struct {
+	target io.Writer
+	rows   []FileStatusRow
+}
+
+func NewFileStatusJSONWriter(target io.Writer) FileStatusWriter {
+	return &fileStatusJSONWriter{
+		target: target,
+		rows:   []FileStatusRow{},
+	}
+}
+
+func (writer *fileStatusJSONWriter) Write(row FileStatusRow) error {
+	writer.rows = append(writer.rows, row)
+
+	return nil
+}
+
+func (writer *fileStatusJSONWriter) Flush() error {
+	return writeJSON(writer.target, writer.rows)
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Smartling/api-sdk-go"
+)
+
+func filterFilesByBranch(
+	files []smartling.File,
+	branch string,
+) ([]smartling.File, error) {
+	if branch == "" {
+		return files, nil
+	}
+
+	var result []smartling.File
+
+	for _, file := range files {
+		if strings.HasPrefix(strings.TrimPrefix(file.FileURI, "/"), branch) {
+			result = append(result, file)
+		}
+	}
+
+	if len(result) == 0 {
+		return nil, NewError(
+			fmt.Errorf(
+				`no files found on the remote server with prefix "%s"`,
+				branch,
+			),
+
+			"Check that branch name is correct.",
+		)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// filterFilesLocally leaves only specified files, which are relative to
+// base directory, and fails if any of specified files is not in the list.
+func filterFilesLocally(
+	files []string,
+	base string,
+	only []string,
+) ([]string, error) {
+	found := map[string]string{}
+
+	for _, file := range files {
+		path, err := filepath.Abs(file)
+		if err != nil {
+			path = file
+		}
+
+		found[path] = file
+	}
+
+	var result []string
+
+	for _, name := range only {
+		path := name
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(base, path)
+		}
+
+		file, ok := found[filepath.Clean(path)]
+		if !ok {
+			return nil, NewError(
+				fmt.Errorf(
+					`file "%s" is not matched by any pattern from config file`,
+					name,
+				),
+
+				`Check, that file path is relative to directory with config `+
+					`file and file is listed in "files" section of config.`,
+			)
+		}
+
+		result = append(result, file)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/Smartling/api-sdk-go"
+)
+
+// filterFilesRemote leaves only project files, which URIs correspond to
+// specified paths relative to directory with config file, and fails if any
+// of specified files is not found in project. URIs are compared ignoring
+// leading slash.
+func filterFilesRemote(
+	files []smartling.File,
+	only []string,
+) ([]smartling.File, error) {
+	found := map[string]smartling.File{}
+
+	for _, file := range files {
+		found[strings.TrimPrefix(file.FileURI, "/")] = file
+	}
+
+	var result []smartling.File
+
+	for _, name := range only {
+		uri := strings.TrimPrefix(filepath.ToSlash(filepath.Clean(name)), "/")
+
+		file, ok := found[uri]
+		if !ok {
+			return nil, NewError(
+				fmt.Errorf(`file "%s" is not found in project`, name),
+
+				`Check, that file path is relative to directory with config `+
+					`file and file is pushed into project.`,
+			)
+		}
+
+		result = append(result, file)
+	}
+
+	return result, nil
+}
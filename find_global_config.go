@@ -0,0 +1,73 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/reconquest/hierr-go"
+)
+
+// getGlobalConfigPaths returns paths of user-wide config files in order of
+// precedence: $XDG_CONFIG_HOME/smartling/smartling.yml (~/.config by
+// default) and ~/.smartling.yml.
+func getGlobalConfigPaths() []string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		logger.Debugf("unable to get home directory: %s", err)
+
+		return nil
+	}
+
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		configHome = filepath.Join(home, ".config")
+	}
+
+	return []string{
+		filepath.Join(configHome, "smartling", defaultConfigName),
+		filepath.Join(home, "."+defaultConfigName),
+	}
+}
+
+// findGlobalConfig returns path to first existing user-wide config file or
+// empty string if there is none.
+func findGlobalConfig() (string, error) {
+	for _, path := range getGlobalConfigPaths() {
+		logger.Debugf("looking for global config file: %q", path)
+
+		_, err := os.Stat(path)
+		if err == nil {
+			logger.Debugf("global config file found: %q", path)
+
+			return path, nil
+		}
+
+		if !os.IsNotExist(err) {
+			return "", hierr.Errorf(
+				err,
+				"unable to find global config file: %q",
+				path,
+			)
+		}
+	}
+
+	return "", nil
+}
+
+// mergeGlobalConfig fills credentials and project ID, which are not set in
+// project config, from global config file.
+func mergeGlobalConfig(config *Config, global Config) {
+	for _, value := range []struct {
+		target *string
+		source string
+	}{
+		{&config.UserID, global.UserID},
+		{&config.Secret, global.Secret},
+		{&config.AccountID, global.AccountID},
+		{&config.ProjectID, global.ProjectID},
+	} {
+		if *value.target == "" {
+			*value.target = value.source
+		}
+	}
+}
@@ -0,0 +1,15 @@
+package main
+
+import (
+	"github.com/Smartling/api-sdk-go"
+)
+
+func getAwaitingAuthorizationCount(
+	status *smartling.FileStatus,
+	translation smartling.FileStatusTranslation,
+) int {
+	return status.TotalStringCount -
+		translation.AuthorizedStringCount -
+		translation.CompletedStringCount -
+		translation.ExcludedStringCount
+}
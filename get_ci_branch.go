@@ -0,0 +1,27 @@
+package main
+
+import (
+	"os"
+)
+
+// ciBranchVariables lists environment variables, which are set by CI
+// systems to the name of the branch being built, in order of precedence.
+var ciBranchVariables = []string{
+	"GITHUB_HEAD_REF",  // GitHub Actions, pull requests only
+	"CI_COMMIT_BRANCH", // GitLab CI
+	"CIRCLE_BRANCH",    // CircleCI
+	"BRANCH_NAME",      // Jenkins multibranch pipelines
+}
+
+// getCIBranch returns branch name and name of the variable it was read
+// from, or empty strings if no CI variable is set.
+func getCIBranch() (string, string) {
+	for _, name := range ciBranchVariables {
+		value := os.Getenv(name)
+		if value != "" {
+			return value, name
+		}
+	}
+
+	return "", ""
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"time"
+
+	"github.com/Smartling/api-sdk-go"
+	"github.com/reconquest/hierr-go"
+)
+
+// getLastModified returns time of last modification of every file
+// translation, keyed by locale ID. Source file is stored under empty locale.
+func getLastModified(
+	client *smartling.Client,
+	project string,
+	file smartling.File,
+) (map[string]time.Time, error) {
+	request := smartling.FileLastModifiedRequest{}
+	request.FileURI = file.FileURI
+
+	modified, err := client.LastModified(project, request)
+	if err != nil {
+		return nil, hierr.Errorf(
+			err,
+			`unable to get last modification time of "%s"`,
+			file.FileURI,
+		)
+	}
+
+	result := map[string]time.Time{
+		"": file.LastUploaded.Time,
+	}
+
+	for _, locale := range modified.Items {
+		result[locale.LocaleID] = locale.LastModified.Time
+	}
+
+	return result, nil
+}
@@ -0,0 +1,16 @@
+// +build linux darwin freebsd netbsd openbsd dragonfly
+
+package main
+
+import (
+	"os/exec"
+	"strings"
+)
+
+func getShellCommand(command string) *exec.Cmd {
+	return exec.Command("/bin/sh", "-c", command)
+}
+
+func quoteShellArgument(argument string) string {
+	return "'" + strings.Replace(argument, "'", `'\''`, -1) + "'"
+}
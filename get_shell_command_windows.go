@@ -0,0 +1,15 @@
+// +build windows
+
+package main
+
+import (
+	"os/exec"
+)
+
+func getShellCommand(command string) *exec.Cmd {
+	return exec.Command("cmd", "/C", command)
+}
+
+func quoteShellArgument(argument string) string {
+	return `"` + argument + `"`
+}
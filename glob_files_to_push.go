@@ -0,0 +1,163 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/reconquest/hierr-go"
+)
+
+func globFilesToPush(
+	config Config,
+	args map[string]interface{},
+) (string, []string, error) {
+	var (
+		file, _     = args["<file>"].(string)
+		uri, _      = args["<uri>"].(string)
+		directory   = args["--directory"].(string)
+		excludes, _ = args["--exclude"].([]string)
+		only, _     = args["--file"].([]string)
+		maxSize, _  = args["--max-file-size"].(string)
+	)
+
+	if file != "" && len(only) > 0 {
+		return "", nil, NewError(
+			fmt.Errorf(`--file can not be used along with <file> pattern`),
+
+			`Use --file to push only some of files configured in config `+
+				`file or <file> to push files by pattern.`,
+		)
+	}
+
+	patterns := []string{}
+
+	if file != "" {
+		patterns = append(patterns, file)
+	} else {
+		for pattern, section := range config.Files {
+			if section.Push.Type != "" {
+				patterns = append(patterns, pattern)
+			}
+		}
+	}
+
+	// patterns from config file are relative to config file location
+	root := directory
+	if file == "" && !filepath.IsAbs(directory) {
+		root = filepath.Join(filepath.Dir(config.path), directory)
+	}
+
+	files := []string{}
+
+	for _, mask := range patterns {
+		base, pattern := getDirectoryFromPattern(mask)
+		chunk, err := globFilesLocally(
+			root,
+			base,
+			pattern,
+		)
+		if err != nil {
+			return "", nil, NewError(
+				hierr.Errorf(
+					err,
+					`unable to find matching files to upload`,
+				),
+
+				`Check, that specified pattern is valid and refer to help for`+
+					` more information about glob patterns.`,
+			)
+		}
+
+		if file == "" && len(chunk) == 0 {
+			return "", nil, NewError(
+				fmt.Errorf(
+					`no files found by pattern "%s" from config file`,
+					mask,
+				),
+
+				`Check, that pattern in configuration file "%s" is valid and `+
+					`matching files exist.`,
+				config.path,
+			)
+		}
+
+		logger.Infof("pattern %q matches %d files", mask, len(chunk))
+
+		for _, path := range chunk {
+			logger.Infof("> %s", path)
+		}
+
+		files = append(files, chunk...)
+	}
+
+	base, err := filepath.Abs(config.path)
+	if err != nil {
+		return "", nil, NewError(
+			hierr.Errorf(
+				err,
+				`unable to resolve absolute path to config`,
+			),
+
+			`It's internal error, please, contact developer for more info`,
+		)
+	}
+
+	base = filepath.Dir(base)
+
+	files, err = ignoreFilesLocally(files, base, config.ignore)
+	if err != nil {
+		return "", nil, err
+	}
+
+	files, err = excludeFilesLocally(files, base, excludes)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if len(only) > 0 {
+		files, err = filterFilesLocally(files, base, only)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	if maxSize != "" {
+		limit, err := parseFileSize(maxSize)
+		if err != nil {
+			return "", nil, NewError(
+				err,
+
+				`Size should be specified in bytes or with KB or MB suffix, `+
+					`e.g. 500KB.`,
+			)
+		}
+
+		files, err = skipLargeFiles(files, limit)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	if len(files) == 0 {
+		return "", nil, NewError(
+			fmt.Errorf(`no files found by specified patterns`),
+
+			`Check command line pattern if any and configuration file for`+
+				` more patterns to search for.`,
+		)
+	}
+
+	if uri != "" && len(files) > 1 {
+		return "", nil, NewError(
+			fmt.Errorf(
+				`more than one file is matching speciifed pattern and <uri>`+
+					` is specified too`,
+			),
+
+			`Either remove <uri> argument or make sure that only one file`+
+				` is matching mask.`,
+		)
+	}
+
+	return base, files, nil
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gobwas/glob"
+	"github.com/reconquest/hierr-go"
+)
+
+// ignoreFileName is name of file, located alongside config file, which lists
+// files that should never be pushed, using .gitignore-like syntax.
+const ignoreFileName = ".smartlingignore"
+
+// loadIgnoreFile reads ignore file and converts every pattern in it into
+// glob masks, which are matched against paths relative to config directory.
+// Missing ignore file is not an error.
+func loadIgnoreFile(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, hierr.Errorf(err, `unable to open ignore file "%s"`, path)
+	}
+
+	defer file.Close()
+
+	var (
+		masks   []string
+		scanner = bufio.NewScanner(file)
+		number  = 0
+	)
+
+	for scanner.Scan() {
+		number++
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		converted, err := convertIgnorePattern(line)
+		if err != nil {
+			return nil, hierr.Errorf(
+				err,
+				`invalid pattern at "%s:%d"`,
+				path,
+				number,
+			)
+		}
+
+		masks = append(masks, converted...)
+	}
+
+	err = scanner.Err()
+	if err != nil {
+		return nil, hierr.Errorf(err, `unable to read ignore file "%s"`, path)
+	}
+
+	return masks, nil
+}
+
+// convertIgnorePattern converts single .gitignore-like pattern into glob
+// masks. Pattern without slash matches file or directory at any depth,
+// pattern with slash is relative to config directory, and pattern matching
+// directory matches everything inside it.
+func convertIgnorePattern(pattern string) ([]string, error) {
+	if strings.HasPrefix(pattern, "!") {
+		return nil, fmt.Errorf(`negated patterns are not supported`)
+	}
+
+	pattern = strings.TrimSuffix(pattern, "/")
+
+	anchored := strings.Contains(pattern, "/")
+
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	candidates := []string{pattern}
+	if !anchored {
+		candidates = append(candidates, "**/"+pattern)
+	}
+
+	var masks []string
+
+	for _, candidate := range candidates {
+		masks = append(masks, candidate, candidate+"/**")
+	}
+
+	for _, mask := range masks {
+		_, err := glob.Compile(mask, '/')
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return masks, nil
+}
+
+// ignoreFilesLocally removes files matched by ignore file masks; paths are
+// relative to given base directory.
+func ignoreFilesLocally(
+	files []string,
+	base string,
+	masks []string,
+) ([]string, error) {
+	patterns, err := compileExcludes(masks)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []string
+
+	for _, file := range files {
+		path, err := filepath.Abs(file)
+		if err == nil {
+			path, err = filepath.Rel(base, path)
+		}
+
+		if err != nil {
+			path = file
+		}
+
+		if isExcluded(filepath.ToSlash(path), patterns) {
+			logger.Infof("ignoring %s (listed in %s)", file, ignoreFileName)
+
+			continue
+		}
+
+		result = append(result, file)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+
+	"github.com/Smartling/api-sdk-go"
+	"github.com/reconquest/hierr-go"
+)
+
+// isEmptyLocaleFile returns true if local file has no contents besides
+// whitespace or, for file types which can be parsed locally, contains no
+// strings. Files which can't be parsed are considered not empty, so they are
+// never overwritten by --overwrite-empty.
+func isEmptyLocaleFile(path string, fileType smartling.FileType) (bool, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return false, hierr.Errorf(err, `unable to read "%s"`, path)
+	}
+
+	contents = bytes.TrimPrefix(contents, []byte("\xef\xbb\xbf"))
+
+	if len(bytes.TrimSpace(contents)) == 0 {
+		return true, nil
+	}
+
+	count, ok, err := countLocalStrings(fileType, contents)
+	if err != nil {
+		logger.Debugf("unable to parse %s: %s", path, err)
+
+		return false, nil
+	}
+
+	return ok && count == 0, nil
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"time"
+
+	"github.com/Smartling/api-sdk-go"
+	"github.com/reconquest/hierr-go"
+)
+
+func isFileChangedSince(
+	client *smartling.Client,
+	project string,
+	file smartling.File,
+	since time.Time,
+) (bool, error) {
+	if file.LastUploaded.After(since) {
+		return true, nil
+	}
+
+	request := smartling.FileLastModifiedRequest{
+		LastModifiedAfter: smartling.UTC{Time: since},
+	}
+
+	request.FileURI = file.FileURI
+
+	modified, err := client.LastModified(project, request)
+	if err != nil {
+		return false, hierr.Errorf(
+			err,
+			`unable to get last modification time of "%s"`,
+			file.FileURI,
+		)
+	}
+
+	for _, locale := range modified.Items {
+		if locale.LastModified.After(since) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/reconquest/hierr-go"
+)
+
+// loadEnvFile sets environment variables from file in KEY=VALUE format.
+// Variables which are already set in environment are not overridden.
+func loadEnvFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return hierr.Errorf(err, `unable to open env file "%s"`, path)
+	}
+
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		text = strings.TrimPrefix(text, "export ")
+
+		spec := strings.SplitN(text, "=", 2)
+		if len(spec) != 2 {
+			return fmt.Errorf(
+				`invalid line %d in env file "%s": should be KEY=VALUE`,
+				line,
+				path,
+			)
+		}
+
+		name := strings.TrimSpace(spec[0])
+		value := strings.TrimSpace(spec[1])
+
+		if len(value) >= 2 &&
+			(value[0] == '"' || value[0] == '\'') &&
+			value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+
+		if _, ok := os.LookupEnv(name); ok {
+			continue
+		}
+
+		err := os.Setenv(name, value)
+		if err != nil {
+			return hierr.Errorf(
+				err,
+				`unable to set environment variable "%s"`,
+				name,
+			)
+		}
+	}
+
+	err = scanner.Err()
+	if err != nil {
+		return hierr.Errorf(err, `unable to read env file "%s"`, path)
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/reconquest/hierr-go"
+)
+
+// parseLocaleFileMap builds mapping from locale IDs to file name formats
+// out of --locale-file-map values. Every value is either <locale>=<format>
+// pair or path to JSON file with object like {"pt-BR": "por/{{.FileURI}}"}.
+// Pairs override values from files regardless of order.
+func parseLocaleFileMap(values []string) (map[string]string, error) {
+	var (
+		result = map[string]string{}
+		pairs  [][]string
+	)
+
+	for _, value := range values {
+		if strings.Contains(value, "=") {
+			pairs = append(pairs, strings.SplitN(value, "=", 2))
+
+			continue
+		}
+
+		mapping, err := loadLocaleFileMap(value)
+		if err != nil {
+			return nil, err
+		}
+
+		for locale, format := range mapping {
+			result[locale] = format
+		}
+	}
+
+	for _, pair := range pairs {
+		if pair[0] == "" || pair[1] == "" {
+			return nil, fmt.Errorf(
+				`invalid --locale-file-map value: %q`,
+				strings.Join(pair, "="),
+			)
+		}
+
+		result[pair[0]] = pair[1]
+	}
+
+	for _, format := range result {
+		_, err := compileFormat(format)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func loadLocaleFileMap(path string) (map[string]string, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, hierr.Errorf(
+			err,
+			`unable to read locale file map "%s"`,
+			path,
+		)
+	}
+
+	var mapping map[string]string
+
+	err = json.Unmarshal(data, &mapping)
+	if err != nil {
+		return nil, hierr.Errorf(
+			err,
+			`unable to parse locale file map "%s"`,
+			path,
+		)
+	}
+
+	for locale, format := range mapping {
+		if locale == "" || format == "" {
+			return nil, fmt.Errorf(
+				`locale file map "%s" contains empty locale or format`,
+				path,
+			)
+		}
+	}
+
+	return mapping, nil
+}
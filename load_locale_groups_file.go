@@ -0,0 +1,60 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/reconquest/hierr-go"
+)
+
+// loadLocaleGroupsFile reads JSON object, which maps group names to lists
+// of locale IDs, e.g. {"spanish": ["es-ES", "es-MX"]}, and returns mapping
+// from locale ID to group name.
+func loadLocaleGroupsFile(path string) (map[string]string, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, hierr.Errorf(
+			err,
+			`unable to read locale groups file "%s"`,
+			path,
+		)
+	}
+
+	var groups map[string][]string
+
+	err = json.Unmarshal(data, &groups)
+	if err != nil {
+		return nil, hierr.Errorf(
+			err,
+			`unable to parse locale groups file "%s"`,
+			path,
+		)
+	}
+
+	result := map[string]string{}
+
+	for group, locales := range groups {
+		if group == "" {
+			return nil, fmt.Errorf(
+				`locale groups file "%s" contains empty group name`,
+				path,
+			)
+		}
+
+		for _, locale := range locales {
+			if previous, ok := result[locale]; ok {
+				return nil, fmt.Errorf(
+					`locale "%s" is listed in both "%s" and "%s" groups`,
+					locale,
+					previous,
+					group,
+				)
+			}
+
+			result[locale] = group
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/Smartling/api-sdk-go"
+	"github.com/reconquest/hierr-go"
+)
+
+// loadLocaleListFile reads target locales from JSON file in the same format
+// as "targetLocales" in project details API response, either as array or as
+// object with "targetLocales" key, e.g. [{"localeId": "es"}].
+func loadLocaleListFile(path string) ([]string, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, hierr.Errorf(err, `unable to read locale list file "%s"`, path)
+	}
+
+	var locales []smartling.Locale
+
+	err = json.Unmarshal(data, &locales)
+	if err != nil {
+		var details smartling.ProjectDetails
+
+		err = json.Unmarshal(data, &details)
+		if err != nil {
+			return nil, hierr.Errorf(
+				err,
+				`unable to parse locale list file "%s"`,
+				path,
+			)
+		}
+
+		locales = details.TargetLocales
+	}
+
+	if len(locales) == 0 {
+		return nil, fmt.Errorf(`locale list file "%s" is empty`, path)
+	}
+
+	var result []string
+
+	for _, locale := range locales {
+		if locale.LocaleID == "" {
+			return nil, fmt.Errorf(
+				`locale list file "%s" contains locale without localeId`,
+				path,
+			)
+		}
+
+		result = append(result, locale.LocaleID)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/reconquest/hierr-go"
+)
+
+// loadLocaleMapFile reads JSON object, which maps Smartling locale IDs to
+// names used in local file paths, e.g. {"en-US": "en"}.
+func loadLocaleMapFile(path string) (map[string]string, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, hierr.Errorf(err, `unable to read locale map file "%s"`, path)
+	}
+
+	var mapping map[string]string
+
+	err = json.Unmarshal(data, &mapping)
+	if err != nil {
+		return nil, hierr.Errorf(
+			err,
+			`unable to parse locale map file "%s"`,
+			path,
+		)
+	}
+
+	for locale, name := range mapping {
+		if locale == "" || name == "" {
+			return nil, fmt.Errorf(
+				`locale map file "%s" contains empty locale or name`,
+				path,
+			)
+		}
+	}
+
+	return mapping, nil
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"net/http"
+	"time"
+)
+
+// LoggingTransport logs every HTTP request, including retried ones, along
+// with response status and duration.
+type LoggingTransport struct {
+	http.RoundTripper
+}
+
+func (transport *LoggingTransport) RoundTrip(
+	request *http.Request,
+) (*http.Response, error) {
+	started := time.Now()
+
+	response, err := transport.RoundTripper.RoundTrip(request)
+
+	duration := time.Since(started)
+
+	if err != nil {
+		logger.Debugf(
+			"%s %s [%d bytes] failed in %s: %s",
+			request.Method,
+			request.URL.Path,
+			request.ContentLength,
+			duration,
+			err,
+		)
+
+		return response, err
+	}
+
+	logger.Debugf(
+		"%s %s [%d bytes] %s in %s",
+		request.Method,
+		request.URL.Path,
+		request.ContentLength,
+		response.Status,
+		duration,
+	)
+
+	return response, nil
+}
@@ -9,6 +9,8 @@ import (
 	"path/filepath"
 	"regexp"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/Smartling/api-sdk-go"
 	"github.com/docopt/docopt-go"
@@ -24,27 +26,76 @@ Add --help option to command to get detailed help for specific command.
 
 Usage:
   smartling-cli [options] [-v]... init --help
-  smartling-cli [options] [-v]... init [--dry-run]
+  smartling-cli [options] [-v]... init [--dry-run] [--non-interactive] [--type=]
+                                    [--format=] [<file>]
+  smartling-cli [options] [-v]... config show --help
+  smartling-cli [options] [-v]... config show [--branch=] [--output=]
   smartling-cli [options] [-v]... projects list --help
   smartling-cli [options] [-v]... projects list [--short]
   smartling-cli [options] [-v]... projects info --help
   smartling-cli [options] [-v]... projects info
+  smartling-cli [options] [-v]... projects stats --help
+  smartling-cli [options] [-v]... projects stats [--sort=] [--min-completion=]
   smartling-cli [options] [-v]... projects locales --help
   smartling-cli [options] [-v]... projects locales [--source] [--short] [--format=]
   smartling-cli [options] [-v]... files list --help
-  smartling-cli [options] [-v]... files list [--format=] [--short] [<uri>]
+  smartling-cli [options] [-v]... files list [--format=] [--short] [--branch=] [--output=]
+                                         [<uri>]
   smartling-cli [options] [-v]... files (pull|get) --help
-  smartling-cli [options] [-v]... files (pull|get) [--locale=]... [--directory=] [--source] [--format=]
-                                               [--progress=] [--retrieve=] [<uri>]
+  smartling-cli [options] [-v]... files (pull|get) [--locale=]... [--locale-filter-regexp=]
+                                               [--directory=] [--source] [--format=]
+                                               [--progress=] [--retrieve=] [--exclude=]...
+                                               [--locale-map=]... [--checksum|--verify]
+                                               [--checksum-file=]
+                                               [--missing-only] [--overwrite-empty]
+                                               [--if-newer] [--since-push]
+                                               [--on-missing-file=]
+                                               [--merge-with-source]
+                                               [--locale-subdirectory]
+                                               [--post-process=]
+                                               [--include-original]
+                                               [--source-locale=] [--atomic]
+                                               [--encoding=] [--bom] [--file=]...
+                                               [--skip-unchanged]
+                                               [--locale-file-map=]...
+                                               [<uri>]
   smartling-cli [options] [-v]... files push --help
   smartling-cli [options] [-v]... files push [(--authorize|--locale=...)] [--branch=] [--type=]
-                                         [--directory=] [--directive=]... [<file>] [<uri>]
+                                         [--directory=] [--directive=]... [--dry-run]
+                                         [--exclude=]... [--file=]... [--watch]
+                                         [--check-only] [--branches=] [--uri-format=]
+                                         [--smart-update] [--force] [--max-file-size=]
+                                         [--namespace=] [--simulate-locale=] [--report=]
+                                         [--min-string-count=] [--hash-algorithm=]
+                                         [--delete-removed]
+                                         [<file>] [<uri>]
+  smartling-cli [options] [-v]... files validate --help
+  smartling-cli [options] [-v]... files validate [--type=] [--directory=]
+                                             [--directive=]... [--exclude=]...
+                                             [<file>]
+  smartling-cli [options] [-v]... files diff --help
+  smartling-cli [options] [-v]... files diff [--branch=] [--type=] [--directory=]
+                                         [--directive=]... [--exclude=]...
+                                         [--strings]
+                                         [<file>] [<uri>]
+  smartling-cli [options] [-v]... files authorize --help
+  smartling-cli [options] [-v]... files authorize [--locale=]... [--wait]
+                                                  [--wait-timeout=] [<uri>]
   smartling-cli [options] [-v]... files rename --help
-  smartling-cli [options] [-v]... files rename <old-uri> <new-uri>
+  smartling-cli [options] [-v]... files rename [--prefix] <old-uri> <new-uri>
   smartling-cli [options] [-v]... files status --help
-  smartling-cli [options] [-v]... files status [--directory=] [--format=] [<uri>]
+  smartling-cli [options] [-v]... files status [--directory=] [--format=] [--output=]
+                                           [--exclude=]... [--since=]
+                                           [--locale-map=]... [--fail-below=]
+                                           [--locale=]... [--summary] [--sort=]
+                                           [--watch|--watch-until-complete]
+                                           [--watch-interval=] [--only-incomplete]
+                                           [--locale-groups=] [--export=] [<uri>]
   smartling-cli [options] [-v]... files delete --help
-  smartling-cli [options] [-v]... files delete <uri>
+  smartling-cli [options] [-v]... files delete [--branch=] [--dry-run] [<uri>]
+  smartling-cli [options] [-v]... files clean --help
+  smartling-cli [options] [-v]... files clean [--directory=] [--dry-run] [--all-pulled]
+                                          [--format=] [--checksum-file=] [<uri>]
   smartling-cli [options] [-v]... files import --help
   smartling-cli [options] [-v]... files import <uri> <file> <locale>
                                            [(--published|--post-translation)]
@@ -57,10 +108,24 @@ Commands:
                            configuration file.
    --dry-run              Do not actually write file, just output it
                            on stdout.
+   --non-interactive      Do not ask any questions, use values from config,
+                           command line options and environment variables.
+   --type <type>          File type for files matching <file> mask.
+   --format <format>      Format for pulled file names.
+  config                  Used to access configuration sub-commands.
+   show                   Display configuration resolved from config file,
+                           environment and command line options along with
+                           list of files to push.
+    --output <type>       Output type: yaml or json.
   projects                Used to access various project sub-commands.
    list                   Lists projects for current account.
     -s --short            Display only project IDs.
    info                   Get project details about specific project.
+   stats                  Display translation progress for every target locale
+                           across all project files.
+    --sort <order>        Sort by locale or completion.
+    --min-completion <p>  Fail if any locale is less than <p> percent
+                           complete.
    locales                Display list of target locales.
     -s --short            Display only target locale IDs.
     --format <format>     Use specified format for listing locales.
@@ -71,10 +136,33 @@ Commands:
                            [default: $FILE_STATUS_FORMAT]
     --directory <dir>     Use another directory as reference to check for
                            local files.
+    --output <type>       Output type: table, json or csv.
+    --since <date>        Show only files changed after specified date.
+    --fail-below <percents>
+                          Fail if any locale is translated less than
+                           specified percents.
+    -l --locale <locale>  Show status only for specified locales.
+    --summary             Show one line per locale for all files.
+    --sort <order>        Sort summary by completion or locale.
+    --watch               Refresh status periodically until interrupted.
+    --watch-until-complete
+                          Refresh status until all locales are translated.
+    --watch-interval <interval>
+                          Refresh status every specified seconds.
+    --only-incomplete     Hide files and locales which are completely
+                           translated.
+    --locale-groups <file>
+                          Show summary with locales aggregated by groups
+                           from JSON file.
+    --export <path>       Write status into file in --output format and
+                           show summary instead.
    list <uri>             Lists files from specified project.
     -s --short            Output only file URI.
     --format <format>     Specifies format to use for file list output.
                            [default: $FILE_LIST_FORMAT]
+    -b --branch <branch>  List only files uploaded with specified branch
+                           prefix.
+    --output <type>       Output type: table or json.
    pull <uri>             Pulls specified files from server.
     --source              Pulls source file as well.
     --progress <done>     Pulls only translations that are at least specified
@@ -87,6 +175,48 @@ Commands:
                            different locales, so format should include locale
                            to create several file paths.
                            [default: $FILE_PULL_FORMAT]
+    --checksum            Store SHA-256 checksum alongside every pulled file
+                           and do not rewrite files which are not changed.
+    --skip-unchanged      Do not rewrite files which contents are not
+                           changed.
+    --verify              Do not download anything, only check local files
+                           against stored checksums.
+    --checksum-file <file>
+                          Store checksums of all pulled files in single
+                           file instead of alongside every file.
+    --locale-filter-regexp <regexp>
+                          Pulls only locales which IDs match specified
+                           regular expression.
+    --missing-only        Download only files which do not exist locally.
+    --overwrite-empty     Download only files which do not exist locally or
+                           contain no strings.
+    --if-newer            Download only files which are modified in project
+                           after local files were written.
+    --since-push          Download only translations which are modified
+                           after source file was last pushed.
+    --on-missing-file <action>
+                          What to do if translation is not found in project:
+                           skip, create-empty or error. Default is skip.
+    --merge-with-source   Fill untranslated strings with source strings.
+    --locale-subdirectory
+                          Store translations as <locale>/<file uri>.
+    --post-process <cmd>  Run shell command after every file is written,
+                           replacing {} with path to file.
+    --include-original    Write source file next to translations, using
+                           source locale in file name format.
+    --source-locale <locale>
+                          Use specified locale for --include-original
+                           instead of project source locale.
+    --atomic              Write downloaded files only if all downloads
+                           succeed.
+    --encoding <name>     Convert files into UTF-8, UTF-16LE, UTF-16BE or
+                           Latin-1 before writing.
+    --bom                 Write byte order mark into UTF-8 or UTF-16 files.
+    --file <path>         Pull translations only of specified source file.
+                           Can be specified several times.
+    --locale-file-map <map>
+                          Use own file name format for locale, in form of
+                           <locale>=<format> or path to JSON file.
    push <file> <uri>      Uploads specified file into Smartling platform.
     -z --authorize        Automatically authorize all locales in specified
                            file. Incompatible with -l option.
@@ -96,9 +226,62 @@ Commands:
                            automatically deduced from extension.
     -r --directive <dir>  Specifies one or more directives to use in push
                            request.
+    --dry-run             Prepare files for upload, but do not actually
+                           upload them.
+    --file <path>         Push only specified file from files configured in
+                           config file. Can be specified several times.
+    --watch               Push files again every time they are changed.
+    --check-only          Fail if files to push do not contain new strings.
+    --branches <list>     Push files under every branch prefix from
+                           comma-separated list concurrently.
+    --uri-format <format> Use specified format for file URIs in project.
+    --smart-update        Skip files which are not changed since last push.
+    --force               Push unchanged files as well, even with
+                           --smart-update, and do not ask to confirm
+                           --delete-removed.
+    --hash-algorithm <name>
+                          Hash function for --smart-update: sha256, sha1,
+                           md5 or xxhash.
+    --delete-removed      Delete files under --branch prefix from project
+                           which are not found locally. Requires --branch.
+    --max-file-size <size>
+                          Skip files larger than specified size, e.g. 2MB.
+    --min-string-count <n>
+                          Skip files with fewer than specified number of
+                           strings.
+    --namespace <name>    Upload strings into specified Smartling namespace.
+    --simulate-locale <locale>
+                          Write pseudo translation of every pushed file into
+                           specified locale.
+    --report <path>       Write results of push as JSON into specified file.
+   validate <file>        Checks credentials, project, config file and files
+                           to push without uploading anything.
+   diff <file> <uri>      Shows count of strings added and removed in local
+                           files comparing to project files.
+   authorize <uri>        Authorizes strings awaiting authorization in
+                           specified files. Original file is uploaded again
+                           with authorization flags, so its upload time is
+                           updated and directives from config are applied.
+    -l --locale <locale>  Authorize only specified locales.
+    --wait                Wait until all strings are authorized.
+    --wait-timeout <time>
+                          Fail if strings are still awaiting authorization
+                           after specified time, e.g. 10m.
    rename <old> <new>     Renames given file by old URI into new URI.
+    --prefix              Treat <old> and <new> as prefixes and rename all
+                           files with <old> prefix.
    delete <uri>           Deletes given file from Smartling. This operation
                            can not be undone, so use with care.
+    -b --branch <branch>  Delete only files with specified branch prefix.
+    --dry-run             List files to delete, but do not delete them.
+   clean <uri>            Removes checksum and hashes files written by pull
+                           and push commands.
+    -d --directory <dir>  Look for checksum files in specified directory.
+    --dry-run             List files to remove, but do not remove them.
+    --all-pulled          Remove translations of <uri> files written by pull
+                           command as well.
+    --checksum-file <file>
+                          Remove specified checksums manifest as well.
    import <uri> <file>    Imports translations for given original file URI with
           <locale>          given locale. Original file mush present on server
                            prior to import.
@@ -113,10 +296,16 @@ Commands:
 
 Options:
   -h --help               Show this help.
-  -c --config <file>      Config file in YAML format.
+  -c --config <file>      Config file in YAML or JSON format.
                            By default CLI will look for file named
                            "smartling.yml" in current directory and in all
-                           intermediate parents, emulating git behavior.
+                           intermediate parents, emulating git behavior,
+                           and then in ~/.config/smartling/smartling.yml
+                           and ~/.smartling.yml. Credentials and project ID
+                           missing in project config file are taken from
+                           the latter ones.
+  --env-file <file>       Load environment variables from specified file
+                           before reading config file.
   -p --project <project>  Project ID to operate on.
                            This option overrides config value "project_id".
   -a --account <account>  Account ID to operate on.
@@ -138,9 +327,39 @@ Options:
   -r --directive <dir>    Directives to add to push request in form of
                            <name>=<value>.
   --dry-run               Do not actually perform action, just log it.
+  --output <type>         Output type for commands which support it, one of:
+                           table, json or csv.
+  --exclude <mask>        Skip files matching specified mask. Can be specified
+                           several times.
+  --locale-map <map>      Use another locale name in local file paths, in form
+                           of <locale>=<name>, e.g. zh-TW=zh_TW. Can be
+                           specified several times.
+  --locale-map-file <file>
+                          Read locale names from JSON file with object like
+                           {"zh-TW": "zh_TW"}.
+  --locale-list-file <file>
+                          Check --locale values against locales from JSON
+                           file instead of requesting them from project.
   --threads <number>      If command can be executed concurrently, it will be
                            executed for at most <number> of threads.
                            [default: 4]
+  --parallel-downloads <number>
+                          Download at most <number> of files concurrently.
+                           Defaults to number of --threads.
+  --parallel-uploads <number>
+                          Upload at most <number> of files concurrently.
+                           By default files are uploaded one by one.
+  --concurrency <number>
+                          Execute at most <number> of API requests at once,
+                           regardless of other options. Default is 10.
+  --retry-count <number>  Retry API request specified number of times if it
+                           fails because of network error, server error or
+                           API rate limits. Default is 3.
+  --retry-delay <delay>   Initial delay between retries of API request, which
+                           is doubled after every attempt. Default is 1s.
+  --timeout <timeout>     Fail if single API request, including all its
+                           retries, is not completed within specified time.
+                           Use 0 to disable timeout. Default is 30s.
   -k --insecure           Skip HTTPS certificate validation.
   --proxy <url>           Use specified URL as proxy server.
   --smartling-url <url>   Specify base Smartling URL, merely for testing
@@ -148,11 +367,25 @@ Options:
   -v --verbose            Sets verbosity level for logging messages. Specify
                            flag several time to increase verbosity. Useful
                            when debugging and investigating unexpected
-                           behavior.
+                           behavior. Setting environment variable
+                           SMARTLING_DEBUG=1 is same as -vv.
+  -q --quiet              Do not output anything except errors. Can not be
+                           used together with --verbose.
 `
 
 var (
 	logger = NewRedactedLog()
+
+	timeouts = &TimeoutTransport{}
+
+	// quiet is set when only errors should be displayed
+	quiet = false
+)
+
+const (
+	defaultRetryCount = 3
+	defaultRetryDelay = time.Second
+	defaultTimeout    = 30 * time.Second
 )
 
 const (
@@ -162,6 +395,9 @@ const (
 	defaultFilesListFormat       = `{{.FileURI}}\t{{.LastUploaded}}\t{{.FileType}}\n`
 	defaultFileStatusFormat      = `{{name .FileURI}}{{with .Locale}}_{{.}}{{end}}{{ext .FileURI}}`
 	defaultFilePullFormat        = `{{name .FileURI}}{{with .Locale}}_{{.}}{{end}}{{ext .FileURI}}`
+
+	// localeSubdirectoryPullFormat is used by pull --locale-subdirectory
+	localeSubdirectoryPullFormat = `{{with .Locale}}{{.}}/{{end}}{{.FileURI}}`
 )
 
 func main() {
@@ -183,6 +419,8 @@ func main() {
 		return key
 	})
 
+	// docopt exits by itself on invalid arguments, so error here means that
+	// usage is malformed
 	args, err := docopt.Parse(usage, nil, false, "smartling "+version, false)
 	if err != nil {
 		panic(err)
@@ -196,6 +434,35 @@ func main() {
 
 	logger.ToggleRedact(true)
 
+	if args["--quiet"].(bool) {
+		if args["--verbose"].(int) > 0 {
+			fmt.Fprintln(
+				logger.GetWriter(),
+				NewError(
+					fmt.Errorf(`--quiet and --verbose can not be used together`),
+					`Remove one of these options and try again.`,
+				),
+			)
+
+			os.Exit(1)
+		}
+
+		stdout, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+		if err != nil {
+			reportError(hierr.Errorf(err, `unable to open %s`, os.DevNull))
+
+			os.Exit(1)
+		}
+
+		os.Stdout = stdout
+		quiet = true
+	}
+
+	if !quiet && os.Getenv("SMARTLING_DEBUG") == "1" &&
+		args["--verbose"].(int) < 2 {
+		args["--verbose"] = 2
+	}
+
 	switch args["--verbose"].(int) {
 	case 0:
 		// nothing do to
@@ -216,7 +483,8 @@ func main() {
 
 	config, err := loadConfig(args)
 	if err != nil {
-		fmt.Println(err)
+		// stdout is discarded with --quiet, but errors should be visible
+		reportError(err)
 
 		os.Exit(1)
 	}
@@ -225,6 +493,9 @@ func main() {
 	case args["init"].(bool):
 		err = doInit(config, args)
 
+	case args["config"].(bool):
+		err = doConfigShow(config, args)
+
 	case args["projects"].(bool):
 		err = doProjects(config, args)
 
@@ -236,18 +507,38 @@ func main() {
 	}
 
 	if err != nil {
+		if expired := timeouts.GetExpired(); len(expired) > 0 {
+			reportError(NewError(
+				fmt.Errorf(
+					"API request timed out after %s",
+					*config.Timeout,
+				),
+
+				"Following operations were still pending:\n\n  %s\n\n"+
+					"Check your network connection or increase --timeout.",
+				strings.Join(expired, "\n  "),
+			))
+
+			os.Exit(2)
+		}
+
 		reportError(err)
+
+		if _, ok := err.(noNewStringsError); ok {
+			os.Exit(3)
+		}
+
 		os.Exit(1)
 	}
 }
 
 func reportError(err error) {
 	switch err := err.(type) {
-	case ProjectNotFoundError, Error:
+	case ProjectNotFoundError, Error, noNewStringsError:
 		fmt.Fprintln(logger.GetWriter(), err)
 
 	default:
-		fmt.Fprintf(logger.GetWriter(), "ERROR: %s", err)
+		fmt.Fprintf(logger.GetWriter(), "ERROR: %s\n", err)
 	}
 }
 
@@ -295,30 +586,77 @@ func loadConfig(args map[string]interface{}) (Config, error) {
 
 	var err error
 
+	if args["--env-file"] != nil {
+		err = loadEnvFile(args["--env-file"].(string))
+		if err != nil {
+			return Config{}, NewError(
+				err,
+
+				`Check, that env file exists and contains lines in `+
+					`KEY=VALUE format.`,
+			)
+		}
+	}
+
+	globalPath, err := findGlobalConfig()
+	if err != nil {
+		return Config{}, NewError(
+			err,
+
+			`Check permissions of config files in your home directory.`,
+		)
+	}
+
 	path, _ := args["--config"].(string)
 	if path == "" {
 		path, err = findConfig(
 			filepath.Join(directory, defaultConfigName),
 		)
 		if err != nil {
-			if !args["init"].(bool) {
+			switch {
+			case args["init"].(bool):
+				path = "smartling.yml"
+
+			case globalPath != "":
+				path = globalPath
+
+			default:
 				return Config{}, NewError(
 					err,
 
 					`Ensure, that config file exists either in the current `+
-						`directory or in any parent directory.`,
+						`directory, in any parent directory or in `+
+						`~/.config/smartling/ or home directory.`,
 				)
-			} else {
-				path = "smartling.yml"
 			}
 		}
 	}
 
+	logger.Infof("using config file: %q", path)
+
 	config, err := NewConfig(path)
 	if err != nil {
 		return config, NewError(
 			hierr.Errorf(err, `failed to load configuration file "%s".`, path),
-			`Check configuration file contents according to documentation.`,
+			`Check configuration file contents according to documentation `+
+				`and that all environment variables used in it are set.`,
+		)
+	}
+
+	err = validateConfig(config)
+	if err != nil {
+		return config, err
+	}
+
+	config.ignore, err = loadIgnoreFile(
+		filepath.Join(filepath.Dir(config.path), ignoreFileName),
+	)
+	if err != nil {
+		return config, NewError(
+			err,
+
+			`Check, that ignore file contains one pattern per line in `+
+				`.gitignore format.`,
 		)
 	}
 
@@ -331,7 +669,29 @@ func loadConfig(args map[string]interface{}) (Config, error) {
 	}
 
 	if config.ProjectID == "" {
-		config.Secret = os.Getenv("SMARTLING_PROJECT_ID")
+		config.ProjectID = os.Getenv("SMARTLING_PROJECT_ID")
+	}
+
+	// environment variables take precedence over global config file, and
+	// init should not copy global credentials into project config file
+	if globalPath != "" && globalPath != path && !args["init"].(bool) {
+		global, err := NewConfig(globalPath)
+		if err != nil {
+			return config, NewError(
+				hierr.Errorf(
+					err,
+					`failed to load global configuration file "%s".`,
+					globalPath,
+				),
+				`Check configuration file contents according to `+
+					`documentation and that all environment variables used `+
+					`in it are set.`,
+			)
+		}
+
+		logger.Infof("using credentials from global config file: %q", globalPath)
+
+		mergeGlobalConfig(&config, global)
 	}
 
 	if args["--user"] != nil {
@@ -350,7 +710,7 @@ func loadConfig(args map[string]interface{}) (Config, error) {
 		config.ProjectID = args["--project"].(string)
 	}
 
-	if !args["init"].(bool) {
+	if !args["init"].(bool) && !args["config"].(bool) {
 		if config.UserID == "" {
 			return config, MissingConfigValueError{
 				ConfigPath: config.path,
@@ -406,6 +766,180 @@ func loadConfig(args map[string]interface{}) (Config, error) {
 		config.Threads = int(threads)
 	}
 
+	if config.ParallelDownloads == 0 {
+		config.ParallelDownloads = config.Threads
+	}
+
+	if config.ParallelUploads == 0 {
+		config.ParallelUploads = 1
+	}
+
+	for _, option := range []struct {
+		name   string
+		target *int
+	}{
+		{"--parallel-downloads", &config.ParallelDownloads},
+		{"--parallel-uploads", &config.ParallelUploads},
+	} {
+		if args[option.name] == nil {
+			continue
+		}
+
+		value, err := strconv.ParseInt(args[option.name].(string), 10, 0)
+		if err != nil || value <= 0 {
+			return config, InvalidConfigValueError{
+				ValueName:   strings.TrimPrefix(option.name, "--"),
+				Description: "should be positive integer number",
+			}
+		}
+
+		*option.target = int(value)
+	}
+
+	if args["--concurrency"] != nil {
+		value, err := strconv.ParseInt(args["--concurrency"].(string), 10, 0)
+		if err != nil || value <= 0 {
+			return config, InvalidConfigValueError{
+				ValueName:   "concurrency",
+				Description: "should be positive integer number",
+			}
+		}
+
+		config.Concurrency = int(value)
+	}
+
+	if config.Concurrency < 0 {
+		return config, InvalidConfigValueError{
+			ValueName:   "concurrency",
+			Description: "should be positive integer number",
+		}
+	}
+
+	if config.Concurrency == 0 {
+		config.Concurrency = defaultConcurrency
+	}
+
+	// options are applied whenever given, and values from config file are
+	// used otherwise, even if they are zero
+	if args["--retry-count"] != nil {
+		retries, err := strconv.ParseInt(args["--retry-count"].(string), 10, 0)
+		if err != nil {
+			retries = -1
+		}
+
+		value := int(retries)
+		config.RetryCount = &value
+	}
+
+	if config.RetryCount == nil {
+		value := defaultRetryCount
+		config.RetryCount = &value
+	}
+
+	if *config.RetryCount < 0 {
+		return config, InvalidConfigValueError{
+			ValueName:   "retry count",
+			Description: "should be non-negative integer number",
+		}
+	}
+
+	if args["--retry-delay"] != nil {
+		delay, err := time.ParseDuration(args["--retry-delay"].(string))
+		if err != nil {
+			delay = -1
+		}
+
+		config.RetryDelay = &delay
+	}
+
+	if config.RetryDelay == nil {
+		delay := defaultRetryDelay
+		config.RetryDelay = &delay
+	}
+
+	if *config.RetryDelay < 0 {
+		return config, InvalidConfigValueError{
+			ValueName:   "retry delay",
+			Description: "should be valid duration, e.g. 500ms or 2s",
+		}
+	}
+
+	// mapping from file is applied on top of config file, so it can be
+	// shared by projects, and --locale-map options override both
+	if args["--locale-map-file"] != nil {
+		mapping, err := loadLocaleMapFile(args["--locale-map-file"].(string))
+		if err != nil {
+			return config, NewError(
+				err,
+
+				`Locale map file should contain JSON object with locale IDs `+
+					`as keys and local names as values.`,
+			)
+		}
+
+		if config.LocaleMap == nil {
+			config.LocaleMap = map[string]string{}
+		}
+
+		for locale, name := range mapping {
+			config.LocaleMap[locale] = name
+		}
+	}
+
+	if args["--locale-list-file"] != nil {
+		config.locales, err = loadLocaleListFile(
+			args["--locale-list-file"].(string),
+		)
+		if err != nil {
+			return config, NewError(
+				err,
+
+				`Locale list file should contain JSON array of locales in `+
+					`the same format as "targetLocales" in project details `+
+					`API response, e.g. [{"localeId": "es"}].`,
+			)
+		}
+	}
+
+	localeMap, _ := args["--locale-map"].([]string)
+
+	for _, mapping := range localeMap {
+		spec := strings.SplitN(mapping, "=", 2)
+		if len(spec) != 2 || spec[0] == "" || spec[1] == "" {
+			return config, InvalidConfigValueError{
+				ValueName:   "locale map",
+				Description: "should be in the form of <locale>=<name>",
+			}
+		}
+
+		if config.LocaleMap == nil {
+			config.LocaleMap = map[string]string{}
+		}
+
+		config.LocaleMap[spec[0]] = spec[1]
+	}
+
+	if args["--timeout"] != nil {
+		timeout, err := time.ParseDuration(args["--timeout"].(string))
+		if err != nil {
+			timeout = -1
+		}
+
+		config.Timeout = &timeout
+	}
+
+	if config.Timeout == nil {
+		timeout := defaultTimeout
+		config.Timeout = &timeout
+	}
+
+	if *config.Timeout < 0 {
+		return config, InvalidConfigValueError{
+			ValueName:   "timeout",
+			Description: "should be valid duration, e.g. 30s or 1m",
+		}
+	}
+
 	return config, nil
 }
 
@@ -448,7 +982,23 @@ func createClient(
 		client.BaseURL = args["--smartling-url"].(string)
 	}
 
-	client.HTTP.Transport = &transport
+	// every attempt of retried request is counted separately, so requests
+	// which are waiting for retry do not block other ones
+	timeouts.RoundTripper = &RetryTransport{
+		RoundTripper: NewConcurrencyTransport(
+			&LoggingTransport{
+				RoundTripper: &transport,
+			},
+			config.Concurrency,
+		),
+
+		Retries: *config.RetryCount,
+		Delay:   *config.RetryDelay,
+	}
+
+	timeouts.Timeout = *config.Timeout
+
+	client.HTTP.Transport = timeouts
 	client.UserAgent = "smartling-cli/" + version
 
 	setLogger(client, logger, args["--verbose"].(int))
@@ -497,6 +1047,9 @@ func doProjects(config Config, args map[string]interface{}) error {
 	case args["locales"].(bool):
 		return doProjectsLocales(client, config, args)
 
+	case args["stats"].(bool):
+		return doProjectsStats(client, config, args)
+
 	}
 
 	return nil
@@ -524,11 +1077,23 @@ func doFiles(config Config, args map[string]interface{}) error {
 	case args["delete"].(bool):
 		return doFilesDelete(client, config, args)
 
+	case args["validate"].(bool):
+		return doFilesValidate(client, config, args)
+
+	case args["diff"].(bool):
+		return doFilesDiff(client, config, args)
+
+	case args["authorize"].(bool):
+		return doFilesAuthorize(client, config, args)
+
 	case args["rename"].(bool):
 		return doFilesRename(client, config, args)
 
 	case args["import"].(bool):
 		return doFilesImport(client, config, args)
+
+	case args["clean"].(bool):
+		return doFilesClean(client, config, args)
 	}
 
 	return nil
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Smartling/api-sdk-go"
+	"github.com/reconquest/hierr-go"
+	"gopkg.in/yaml.v2"
+)
+
+// isMergeWithSourceSupported returns true if translation of given file type
+// can be merged with source file.
+func isMergeWithSourceSupported(fileType smartling.FileType) bool {
+	switch fileType {
+	case smartling.FileTypeJSON, smartling.FileTypeYAML:
+		return true
+	}
+
+	return false
+}
+
+// mergeWithSource fills empty and missing strings in translation with strings
+// from source file under the same keys. Order of keys is preserved.
+func mergeWithSource(
+	fileType smartling.FileType,
+	translation []byte,
+	source []byte,
+) ([]byte, error) {
+	// JSON document is valid YAML document, and YAML decoder preserves order
+	// of keys when decoding into map slice, which is important to keep diffs
+	// of pulled files small
+	var target, fallback yaml.MapSlice
+
+	err := yaml.Unmarshal(translation, &target)
+	if err != nil {
+		return nil, hierr.Errorf(err, `unable to parse translated file`)
+	}
+
+	err = yaml.Unmarshal(source, &fallback)
+	if err != nil {
+		return nil, hierr.Errorf(err, `unable to parse source file`)
+	}
+
+	merged := mergeValues(target, fallback)
+
+	if fileType == smartling.FileTypeYAML {
+		return yaml.Marshal(merged)
+	}
+
+	compact := &bytes.Buffer{}
+
+	err = writeOrderedJSON(compact, merged)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &bytes.Buffer{}
+
+	err = json.Indent(result, compact.Bytes(), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	result.WriteString("\n")
+
+	return result.Bytes(), nil
+}
+
+func mergeValues(target interface{}, source interface{}) interface{} {
+	switch target := target.(type) {
+	case nil:
+		return source
+
+	case string:
+		if target == "" {
+			if _, ok := source.(string); ok {
+				return source
+			}
+		}
+
+	case yaml.MapSlice:
+		source, ok := source.(yaml.MapSlice)
+		if !ok {
+			return target
+		}
+
+		merged := yaml.MapSlice{}
+		found := map[interface{}]bool{}
+
+		for _, item := range target {
+			found[item.Key] = true
+
+			merged = append(merged, yaml.MapItem{
+				Key:   item.Key,
+				Value: mergeValues(item.Value, getMapSliceValue(source, item.Key)),
+			})
+		}
+
+		// keys which are not translated at all are taken from source as is
+		for _, item := range source {
+			if !found[item.Key] {
+				merged = append(merged, item)
+			}
+		}
+
+		return merged
+
+	case []interface{}:
+		source, ok := source.([]interface{})
+		if !ok {
+			return target
+		}
+
+		merged := make([]interface{}, len(target))
+
+		for index, item := range target {
+			if index < len(source) {
+				item = mergeValues(item, source[index])
+			}
+
+			merged[index] = item
+		}
+
+		return merged
+	}
+
+	return target
+}
+
+func getMapSliceValue(slice yaml.MapSlice, key interface{}) interface{} {
+	for _, item := range slice {
+		if item.Key == key {
+			return item.Value
+		}
+	}
+
+	return nil
+}
+
+// writeOrderedJSON encodes value as JSON, keeping order of keys in maps
+// decoded by YAML decoder.
+func writeOrderedJSON(buffer *bytes.Buffer, value interface{}) error {
+	switch value := value.(type) {
+	case yaml.MapSlice:
+		buffer.WriteString("{")
+
+		for index, item := range value {
+			if index > 0 {
+				buffer.WriteString(",")
+			}
+
+			key, err := json.Marshal(fmt.Sprint(item.Key))
+			if err != nil {
+				return err
+			}
+
+			buffer.Write(key)
+			buffer.WriteString(":")
+
+			err = writeOrderedJSON(buffer, item.Value)
+			if err != nil {
+				return err
+			}
+		}
+
+		buffer.WriteString("}")
+
+	case []interface{}:
+		buffer.WriteString("[")
+
+		for index, item := range value {
+			if index > 0 {
+				buffer.WriteString(",")
+			}
+
+			err := writeOrderedJSON(buffer, item)
+			if err != nil {
+				return err
+			}
+		}
+
+		buffer.WriteString("]")
+
+	default:
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+
+		buffer.Write(data)
+	}
+
+	return nil
+}
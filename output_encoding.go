@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// OutputEncoding is encoding of files written by pull command, which are
+// converted from UTF-8 returned by API.
+type OutputEncoding struct {
+	Name string
+	BOM  bool
+}
+
+// parseOutputEncoding returns encoding by its name, e.g. UTF-16LE or
+// Latin-1, or nil if files should be written as is.
+func parseOutputEncoding(name string, bom bool) (*OutputEncoding, error) {
+	normalized := strings.NewReplacer("-", "", "_", "").Replace(
+		strings.ToLower(name),
+	)
+
+	switch normalized {
+	case "", "utf8":
+		if !bom {
+			return nil, nil
+		}
+
+		normalized = "utf8"
+
+	case "utf16", "utf16le":
+		normalized = "utf16le"
+
+	case "utf16be":
+		// valid
+
+	case "latin1", "iso88591":
+		if bom {
+			return nil, fmt.Errorf(`byte order mark can't be used with Latin-1`)
+		}
+
+		normalized = "latin1"
+
+	default:
+		return nil, fmt.Errorf(`unsupported encoding: %q`, name)
+	}
+
+	return &OutputEncoding{Name: normalized, BOM: bom}, nil
+}
+
+// Encode converts UTF-8 data into encoding. Byte order mark returned by API,
+// if any, is written only if it's requested.
+func (encoding *OutputEncoding) Encode(data []byte) ([]byte, error) {
+	if !utf8.Valid(data) {
+		return nil, fmt.Errorf(`file contents are not valid UTF-8`)
+	}
+
+	text := strings.TrimPrefix(string(data), "\uFEFF")
+
+	if encoding.BOM {
+		text = "\uFEFF" + text
+	}
+
+	buffer := &bytes.Buffer{}
+
+	switch encoding.Name {
+	case "utf8":
+		buffer.WriteString(text)
+
+	case "utf16le", "utf16be":
+		var order binary.ByteOrder = binary.LittleEndian
+		if encoding.Name == "utf16be" {
+			order = binary.BigEndian
+		}
+
+		unit := make([]byte, 2)
+
+		for _, code := range utf16.Encode([]rune(text)) {
+			order.PutUint16(unit, code)
+			buffer.Write(unit)
+		}
+
+	case "latin1":
+		for _, char := range text {
+			if char > 0xFF {
+				return nil, fmt.Errorf(
+					`character %q can't be represented in Latin-1`,
+					char,
+				)
+			}
+
+			buffer.WriteByte(byte(char))
+		}
+	}
+
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseFileSize parses size in bytes with optional KB or MB suffix, e.g.
+// 500KB or 2MB; suffixes are case-insensitive and use 1024 multiplier.
+func parseFileSize(value string) (int64, error) {
+	size := strings.ToUpper(strings.TrimSpace(value))
+
+	multiplier := int64(1)
+
+	switch {
+	case strings.HasSuffix(size, "MB"):
+		multiplier = 1024 * 1024
+		size = strings.TrimSuffix(size, "MB")
+
+	case strings.HasSuffix(size, "KB"):
+		multiplier = 1024
+		size = strings.TrimSuffix(size, "KB")
+
+	case strings.HasSuffix(size, "B"):
+		size = strings.TrimSuffix(size, "B")
+	}
+
+	number, err := strconv.ParseFloat(strings.TrimSpace(size), 64)
+	if err != nil || number <= 0 {
+		return 0, fmt.Errorf(`invalid file size: %q`, value)
+	}
+
+	return int64(number * float64(multiplier)), nil
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"os"
+	"sync"
+)
+
+// PendingWrites keeps downloaded files, which are written into temporary
+// files and should be moved into place only if all downloads succeed. It's
+// safe for concurrent use.
+type PendingWrites struct {
+	sync.Mutex
+
+	temps   []string
+	commits []func() error
+}
+
+// Add registers temporary file along with function, which moves it into
+// place.
+func (pending *PendingWrites) Add(temp string, commit func() error) {
+	pending.Lock()
+	defer pending.Unlock()
+
+	pending.temps = append(pending.temps, temp)
+	pending.commits = append(pending.commits, commit)
+}
+
+// Commit moves all registered files into place and returns count of
+// written files. Commit stops on first error, and files which are not
+// moved yet are discarded.
+func (pending *PendingWrites) Commit() (int, error) {
+	pending.Lock()
+	defer pending.Unlock()
+
+	for index, commit := range pending.commits {
+		err := commit()
+		if err != nil {
+			for _, temp := range pending.temps[index+1:] {
+				os.Remove(temp)
+			}
+
+			return index, err
+		}
+	}
+
+	return len(pending.commits), nil
+}
+
+// Discard removes all registered temporary files and returns their count,
+// so existing files are left untouched.
+func (pending *PendingWrites) Discard() int {
+	pending.Lock()
+	defer pending.Unlock()
+
+	for _, temp := range pending.temps {
+		os.Remove(temp)
+	}
+
+	return len(pending.temps)
+}
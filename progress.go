@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"os"
 	"sync"
 )
 
@@ -14,8 +15,15 @@ type Progress struct {
 	Renderer ProgressRenderer
 }
 
-func (progress *Progress) String() string {
-	return fmt.Sprintf("%d/%d", progress.Current, progress.Total)
+// ProgressState is snapshot of progress, which is passed to renderer, so
+// progress is not copied along with its lock.
+type ProgressState struct {
+	Current int
+	Total   int
+}
+
+func (state ProgressState) String() string {
+	return fmt.Sprintf("%d/%d", state.Current, state.Total)
 }
 
 func (progress *Progress) Increment() {
@@ -25,6 +33,42 @@ func (progress *Progress) Increment() {
 	progress.Current++
 }
 
-func (progress Progress) Flush() {
-	progress.Renderer.Render(progress)
+func (progress *Progress) Flush() {
+	if quiet {
+		return
+	}
+
+	progress.Lock()
+	defer progress.Unlock()
+
+	progress.Renderer.Render(ProgressState{
+		Current: progress.Current,
+		Total:   progress.Total,
+	})
+}
+
+// Done increments progress and writes line about written file along with
+// its number, e.g. "[12/80] fr-FR messages_fr-FR.json". Line is written
+// under lock, so numbers are never out of order.
+func (progress *Progress) Done(locale string, path string) {
+	progress.Lock()
+	defer progress.Unlock()
+
+	progress.Current++
+
+	if quiet {
+		return
+	}
+
+	state := ProgressState{
+		Current: progress.Current,
+		Total:   progress.Total,
+	}
+
+	// source files have no locale
+	if locale == "" {
+		fmt.Fprintf(os.Stderr, "[%s] %s\n", state, path)
+	} else {
+		fmt.Fprintf(os.Stderr, "[%s] %s %s\n", state, locale, path)
+	}
 }
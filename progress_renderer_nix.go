@@ -9,8 +9,8 @@ import (
 
 type ProgressRenderer struct{}
 
-func (renderer ProgressRenderer) Render(progress Progress) error {
-	_, err := fmt.Fprintf(os.Stderr, "%s\r", progress.String())
+func (renderer ProgressRenderer) Render(state ProgressState) error {
+	_, err := fmt.Fprintf(os.Stderr, "%s\r", state.String())
 
 	return err
 }
@@ -43,7 +43,7 @@ type (
 
 type ProgressRenderer struct{}
 
-func (renderer ProgressRenderer) Render(progress Progress) error {
+func (renderer ProgressRenderer) Render(state ProgressState) error {
 	var info consoleScreenBufferInfo
 
 	_, _, code := syscall.Syscall(
@@ -71,7 +71,7 @@ func (renderer ProgressRenderer) Render(progress Progress) error {
 		return error(code)
 	}
 
-	_, err := fmt.Fprint(os.Stderr, progress.String())
+	_, err := fmt.Fprint(os.Stderr, state.String())
 
 	return err
 }
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"regexp"
+)
+
+// PullOptions holds values, which are parsed from pull command line
+// options once, before any file is pulled.
+type PullOptions struct {
+	// Checksums are loaded from --checksum-file.
+	Checksums *FileHashes
+
+	// Pending collects downloaded files, if --atomic is given.
+	Pending *PendingWrites
+
+	// Encoding of written files, nil means UTF-8 without byte order mark.
+	Encoding *OutputEncoding
+
+	// LocaleFileMap is parsed --locale-file-map.
+	LocaleFileMap map[string]string
+
+	// LocaleFilter is compiled --locale-filter-regexp.
+	LocaleFilter *regexp.Regexp
+
+	// SourceLocale is set only along with --include-original, either from
+	// --source-locale or from project details.
+	SourceLocale string
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/Smartling/api-sdk-go"
+)
+
+// pushBranches uploads same files under every specified branch prefix
+// concurrently and reports result for every branch.
+func pushBranches(
+	client *smartling.Client,
+	config Config,
+	args map[string]interface{},
+	options PushOptions,
+	base string,
+	files []string,
+	branches []string,
+) error {
+	var (
+		group  sync.WaitGroup
+		failed = make([]bool, len(branches))
+	)
+
+	for index, branch := range branches {
+		// every branch needs own args, because branch is read from them
+		branchArgs := map[string]interface{}{}
+		for key, value := range args {
+			branchArgs[key] = value
+		}
+
+		branchArgs["--branch"] = branch
+
+		group.Add(1)
+
+		go func(index int, branch string, args map[string]interface{}) {
+			defer group.Done()
+
+			for _, file := range files {
+				err := pushFile(client, config, args, options, base, file)
+				if err != nil {
+					logger.Error(err)
+
+					failed[index] = true
+
+					return
+				}
+			}
+		}(index, branch, branchArgs)
+	}
+
+	group.Wait()
+
+	var failures []string
+
+	for index, branch := range branches {
+		if failed[index] {
+			fmt.Printf("branch %s failed\n", branch)
+
+			failures = append(failures, branch)
+		} else {
+			fmt.Printf("branch %s pushed [%d files]\n", branch, len(files))
+		}
+	}
+
+	if len(failures) > 0 {
+		return NewError(
+			fmt.Errorf(
+				`unable to push files to branches: %s`,
+				strings.Join(failures, ", "),
+			),
+
+			`Check errors above and push files to failed branches again.`,
+		)
+	}
+
+	return nil
+}
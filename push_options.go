@@ -0,0 +1,14 @@
+package main
+
+// PushOptions holds values, which are parsed from push command line
+// options once, before any file is pushed.
+type PushOptions struct {
+	// Report collects results of pushed files, if --report is given.
+	Report *PushReport
+
+	// Hashes of files from last push, if --smart-update is given.
+	Hashes *FileHashes
+
+	// MinStringCount is value of --min-string-count.
+	MinStringCount int
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/reconquest/hierr-go"
+)
+
+// PushReportEntry is result of pushing single file, which is written into
+// --report file.
+type PushReportEntry struct {
+	File    string `json:"file"`
+	URI     string `json:"uri"`
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Pushed  bool   `json:"pushed"`
+	Deleted bool   `json:"deleted"`
+	Strings int    `json:"strings"`
+	Words   int    `json:"words"`
+	Error   string `json:"error,omitempty"`
+}
+
+// PushReport collects results of pushed files. It's safe for concurrent
+// use.
+type PushReport struct {
+	sync.Mutex
+
+	path    string
+	entries []PushReportEntry
+}
+
+func NewPushReport(path string) *PushReport {
+	return &PushReport{
+		path:    path,
+		entries: []PushReportEntry{},
+	}
+}
+
+func (report *PushReport) Add(entry PushReportEntry) {
+	report.Lock()
+	defer report.Unlock()
+
+	report.entries = append(report.entries, entry)
+}
+
+// Write writes all collected results as JSON array sorted by file path and
+// URI.
+func (report *PushReport) Write() error {
+	report.Lock()
+	defer report.Unlock()
+
+	sort.SliceStable(report.entries, func(i, j int) bool {
+		if report.entries[i].File == report.entries[j].File {
+			return report.entries[i].URI < report.entries[j].URI
+		}
+
+		return report.entries[i].File < report.entries[j].File
+	})
+
+	file, err := os.Create(report.path)
+	if err != nil {
+		return hierr.Errorf(
+			err,
+			`unable to create report file "%s"`,
+			report.path,
+		)
+	}
+
+	defer file.Close()
+
+	return writeJSON(file, report.entries)
+}
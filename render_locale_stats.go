@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// renderLocaleStats writes table with completion of every locale in given
+// order followed by overall completion.
+func renderLocaleStats(stats []LocaleStats) error {
+	overall := LocaleStats{
+		Locale: "overall",
+	}
+
+	table := NewTableWriter(os.Stdout)
+
+	for _, locale := range stats {
+		fmt.Fprintf(
+			table,
+			"%s\t%d%%\t%d\t%d\n",
+			locale.Locale,
+			int(locale.Completion()),
+			locale.Completed,
+			locale.Total,
+		)
+
+		overall.Completed += locale.Completed
+		overall.Total += locale.Total
+	}
+
+	fmt.Fprintf(
+		table,
+		"%s\t%d%%\t%d\t%d\n",
+		overall.Locale,
+		int(overall.Completion()),
+		overall.Completed,
+		overall.Total,
+	)
+
+	return RenderTable(table)
+}
+
+// groupLocaleStats sums stats of locales, which belong to the same group,
+// under group name. Locales which are not listed in groups are kept as is.
+func groupLocaleStats(
+	completion map[string]*LocaleStats,
+	groups map[string]string,
+) []LocaleStats {
+	var (
+		stats   = []LocaleStats{}
+		grouped = map[string]*LocaleStats{}
+	)
+
+	for _, locale := range completion {
+		group, ok := groups[locale.Locale]
+		if !ok {
+			stats = append(stats, *locale)
+
+			continue
+		}
+
+		if _, ok := grouped[group]; !ok {
+			grouped[group] = &LocaleStats{Locale: group}
+		}
+
+		grouped[group].Completed += locale.Completed
+		grouped[group].Total += locale.Total
+	}
+
+	for _, group := range grouped {
+		stats = append(stats, *group)
+	}
+
+	return stats
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/reconquest/hierr-go"
+)
+
+func resolveBranch(branch string) (string, error) {
+	if branch == "@auto" {
+		var variable string
+
+		branch, variable = getCIBranch()
+		if branch != "" {
+			logger.Infof(
+				"autodetected branch name from $%s: %s",
+				variable,
+				branch,
+			)
+		} else {
+			var err error
+
+			branch, err = getGitBranch()
+			if err != nil {
+				return "", hierr.Errorf(
+					err,
+					"unable to autodetect branch name",
+				)
+			}
+
+			logger.Infof("autodetected branch name from git: %s", branch)
+		}
+	}
+
+	if branch != "" {
+		branch = strings.TrimSuffix(branch, "/") + "/"
+	}
+
+	return branch, nil
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryTransport retries requests which failed because of network errors,
+// server errors (HTTP 5xx) or API rate limits (HTTP 429).
+type RetryTransport struct {
+	http.RoundTripper
+
+	Retries int
+	Delay   time.Duration
+}
+
+type retryableStatusError struct {
+	Path   string
+	Status string
+}
+
+func (err retryableStatusError) Error() string {
+	return fmt.Sprintf("request to %s failed: %s", err.Path, err.Status)
+}
+
+func (transport *RetryTransport) RoundTrip(
+	request *http.Request,
+) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 && request.GetBody != nil {
+			body, err := request.GetBody()
+			if err != nil {
+				return nil, err
+			}
+
+			request = request.WithContext(request.Context())
+			request.Body = body
+		}
+
+		response, err := transport.RoundTripper.RoundTrip(request)
+		if err == nil &&
+			response.StatusCode != http.StatusTooManyRequests &&
+			response.StatusCode < http.StatusInternalServerError {
+			return response, nil
+		}
+
+		// last response is returned as is, so API client can read its body;
+		// after timeout is reached there is no sense to retry either
+		if attempt > transport.Retries || request.Context().Err() != nil {
+			return response, err
+		}
+
+		delay := getRetryBackoff(transport.Delay, attempt)
+
+		if err == nil {
+			if response.StatusCode == http.StatusTooManyRequests {
+				if after, ok := getRetryAfter(response); ok {
+					delay = after
+				}
+			}
+
+			response.Body.Close()
+
+			err = retryableStatusError{
+				Path:   request.URL.Path,
+				Status: response.Status,
+			}
+		}
+
+		logger.Infof(
+			"%s, retrying in %s (%d/%d)",
+			err,
+			delay,
+			attempt,
+			transport.Retries,
+		)
+
+		timer := time.NewTimer(delay)
+
+		select {
+		case <-timer.C:
+			// next attempt
+
+		case <-request.Context().Done():
+			timer.Stop()
+
+			return nil, request.Context().Err()
+		}
+	}
+}
+
+// getRetryBackoff returns delay before next attempt: initial delay doubled
+// after every attempt, with random jitter to not retry concurrent requests
+// at the same time.
+func getRetryBackoff(delay time.Duration, attempt int) time.Duration {
+	backoff := delay * time.Duration(1<<uint(attempt-1))
+	if backoff > 0 {
+		backoff = backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+	}
+
+	return backoff
+}
+
+// getRetryAfter returns delay requested by server in Retry-After header,
+// which is either number of seconds or HTTP date.
+func getRetryAfter(response *http.Response) (time.Duration, bool) {
+	header := response.Header.Get("Retry-After")
+	if header == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil {
+		return time.Duration(seconds) * time.Second, seconds >= 0
+	}
+
+	if date, err := http.ParseTime(header); err == nil {
+		delay := time.Until(date)
+		if delay < 0 {
+			delay = 0
+		}
+
+		return delay, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/reconquest/hierr-go"
+)
+
+// runPostProcess runs specified shell command with both {} and %s replaced
+// by quoted path to written file.
+func runPostProcess(command string, path string) error {
+	command = strings.NewReplacer(
+		"{}", quoteShellArgument(path),
+		"%s", quoteShellArgument(path),
+	).Replace(command)
+
+	logger.Infof("running post-process command: %s", command)
+
+	output, err := getShellCommand(command).CombinedOutput()
+	if err != nil {
+		return hierr.Errorf(
+			err,
+			"post-process command failed for %s: %s\n%s",
+			path,
+			command,
+			strings.TrimSpace(string(output)),
+		)
+	}
+
+	if len(output) > 0 {
+		logger.Infof("%s", strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
@@ -53,6 +53,17 @@ overwritting anything:
 
   smartling-cli init --dry-run
 
+Init will also ask for file mask, which should be pushed by default, file
+type for matching files and format for pulled file names. These values can
+be passed prior dialog as well:
+
+  smartling-cli init --type=json --format='{{.Locale}}/{{.FileURI}}' 'locales/en/*.json'
+
+To create config without any dialog, e.g. in bootstrapping scripts, use
+--non-interactive option. In that case all values are taken from command line
+options, environment variables ($SMARTLING_USER_ID, $SMARTLING_SECRET,
+$SMARTLING_PROJECT_ID) or existing config file.
+
 By default, smartling.yml file in the local directory will be used as target
 config file, but it can be overriden by using --config option:
 
@@ -66,12 +77,40 @@ Available options:
   --dry-run
     Do not overwrite config file, only output to stdout.
 
+  --non-interactive
+    Do not ask any questions, use values passed via options.
+
+  --type <type>
+    File type for files matching <file> mask. Deduced from extension if
+    not specified.
+
+  --format <format>
+    Format for pulled file names.
+
 Default config values can be passed via following options:` +
 	authenticationOptionsHelp + `
   -p --project <project>
     Specify default project.
 `
 
+const configShowHelp = `smartling-cli config show — display resolved configuration.
+
+Displays configuration which is actually used by other commands, after
+config file is loaded, environment variables are substituted and command
+line options are applied. Secret and proxy password are masked.
+
+List of files, which will be uploaded by "files push" command, is displayed
+as well, along with file URI, file type and directives, which will be used
+for every file.
+
+Available options:
+  -b --branch <branch>
+    Display file URIs prefixed with specified branch.
+
+  --output <type>
+    Output type: yaml (default) or json.
+` + authenticationOptionsHelp
+
 const projectsListHelp = `smartling-cli projects list — list projects from account.
 
 Command will list projects from specified account in tabular format with
@@ -125,6 +164,36 @@ Available options:
     Use specific output format instead of default.
 ` + authenticationOptionsHelp
 
+const projectsStatsHelp = `smartling-cli projects stats — show translation progress.
+
+Aggregates translation status of all project files and shows completion
+percentage for every target locale along with overall project completion.
+
+Following columns are displayed:
+
+  > Locale ID
+  > Completion Percentage
+  > Completed Strings Count
+  > Total Strings Count
+
+Output is sorted by locale ID by default. To sort locales by completion
+percentage use --sort completion.
+
+To use command as release check in CI, use --min-completion option:
+command will fail if any locale is less than specified percent complete.
+
+
+Available options:
+  -p --project <project>
+    Specify project to use.
+
+  --sort <order>
+    Sort by "locale" (default) or by "completion".
+
+  --min-completion <percent>
+    Fail if any locale completion is below specified percent.
+` + authenticationOptionsHelp
+
 const filesListHelp = `smartling-cli files list — list files from project.
 
 Lists all files from project or only files which matches specified uri.
@@ -146,6 +215,14 @@ Following variables are available:
   > .LastUploaded — timestamp when file was last uploaded;
   > .HasInstructions — true/false if file has translation instructions;
 
+To list only files pushed with specific --branch option, use the same --branch
+option for list command. Special value "@auto" is supported as well. It's
+useful to find out stale files after branch is merged:
+
+  smartling-cli files list --branch feature-x --short
+
+To use list in scripts, --output option can be set to "json".
+
 <uri> ` + globPatternHelp + `
 
 
@@ -158,6 +235,12 @@ Available options:
 
   --format <format>
     Override default listing format.
+
+  --branch <branch>
+    List only files with specified branch prefix.
+
+  --output <type>
+    Output type: table (default) or json.
 ` + authenticationOptionsHelp
 
 const filesPullHelp = `smartling-cli files pull — downloads translated files from project.
@@ -176,13 +259,147 @@ to read files list from stdin:
 <uri> ` + globPatternHelp + `
 
 If --locale flag is not specified, all available locales are downloaded. To
-see available locales, use "status" command.
+see available locales, use "status" command. Several locales can be given
+either by repeating --locale flag or as comma-separated list:
+
+  smartling-cli files pull --locale fr-FR,de-DE
+
+Specified locales are checked against project target locales before any
+file is downloaded.
+
+To download group of locales without listing them, use --locale-filter-regexp
+option with regular expression to match locale IDs against, e.g. all French
+locales:
+
+  smartling-cli files pull --locale-filter-regexp '^fr-'
 
 To download files into subdirectory, use --directory option and specify
 directory name you want to download into.
 
 To download source file as well as translated files specify --source option.
 
+To pull translations only of some source files, e.g. right after pushing
+them, use one or several --file options with file path relative to directory
+with config file, which is the same path as used for push --file. Command will
+fail if specified file is not found in project. This option can't be used
+along with <uri>.
+
+While files are downloading, every written file is displayed on stderr
+along with its number and total count of files, e.g.:
+
+  [12/80] fr-FR translations/messages_fr-FR.json
+
+Use --quiet to hide it.
+
+Downloaded files are written into temporary file next to the target one and
+renamed when download is complete, so interrupted pull does not leave
+partially written files, and files are not held in memory while written.
+
+When --checksum option is given, SHA-256 checksum of every downloaded file
+is stored alongside it in "<file>.sha256" in format compatible with
+sha256sum tool. Files which contents have not changed since previous pull
+are not rewritten, so file watchers are not triggered.
+
+To not rewrite unchanged files without storing checksums, use
+--skip-unchanged option. Downloaded file is compared with existing local file
+byte by byte and is written only if contents differ, so modification times of
+unchanged files are kept and build tools do not rebuild them. Such files are
+reported as "not changed".
+
+To check, that local files are not corrupted or modified, use --verify
+option: no files will be downloaded, but every local file will be checked
+against stored checksum. Command will fail if any file is missing or does
+not match its checksum. Files which fail verification are listed.
+
+To keep checksums of all pulled files in single manifest file instead of
+"<file>.sha256" files, use --checksum-file option along with --checksum or
+--verify. Manifest is written in sha256sum format with paths of pulled files
+and updated after every written file, so it's not lost if pull is
+interrupted, e.g.:
+
+  smartling-cli files pull --checksum --checksum-file .smartling-checksums
+  smartling-cli files pull --verify --checksum-file .smartling-checksums
+
+To download only files which are missing locally, use --missing-only option.
+Existing files are not checked for freshness, so it's useful to fill gaps
+after incremental builds or with cached directories.
+
+To download missing files and overwrite only local files which are empty or
+contain no strings, e.g. locales which are not bootstrapped yet, use
+--overwrite-empty option. Files with only whitespace are considered empty,
+and JSON, YAML, Java properties and plain text files are considered empty if
+they contain no strings. Files of other types or which can't be parsed are
+overwritten only if they are blank. It can be enabled for some files only
+with "overwrite_empty: true" in pull section of config file.
+
+If translation of file is not found in project, it's skipped with warning.
+To change that, use --on-missing-file option with one of following values:
+
+  > skip — log warning and continue with other files (default);
+  > create-empty — create empty file in place of missing translation;
+  > error — report error for every missing translation and fail command.
+
+To get files usable by applications without fallback logic, use
+--merge-with-source option. Empty and missing strings in translated files are
+filled with strings from source file under the same keys, while order of keys
+is kept. Only JSON and YAML files can be merged; other files are downloaded
+as is with warning.
+
+To process every downloaded file, e.g. to minify or convert it, use
+--post-process option with shell command; {} in command is replaced with
+quoted path to written file, and printf-style string placeholder can be used
+instead of {} as well. Command is run right after file is written and
+before next file is downloaded. If command exits with non-zero code, pull of
+that locale is considered failed:
+
+  --post-process 'prettier --write {}'
+
+Files are written in UTF-8, as they are returned by API. For applications
+which require other encoding, use --encoding option with one of following
+values (case and dashes are ignored):
+
+  > UTF-8 — default, files are written as is;
+  > UTF-16LE or UTF-16 — UTF-16 with little-endian byte order;
+  > UTF-16BE — UTF-16 with big-endian byte order;
+  > Latin-1 or ISO-8859-1 — file fails to be written if it contains
+    characters which can't be represented in Latin-1.
+
+To prepend byte order mark to UTF-8 or UTF-16 files, e.g. for Windows
+applications, use --bom option. Checksums are computed over converted files.
+
+To compare translations with source side by side, use --include-original
+option. Original file is downloaded along with translations and written
+under the same file name format, with project source locale used as locale,
+e.g. "strings_en-US.json" next to "strings_fr-FR.json". Locale mapping is
+applied to source locale as well. Use --source-locale option to use another
+locale name for original files.
+
+Errors are reported for every file which can't be pulled, and command fails
+after all other files are downloaded.
+
+To not leave local files partially updated when some downloads fail, use
+--atomic option. Downloaded files are kept in temporary files next to target
+ones until all downloads are completed, and are moved into place only if
+every download succeeded; otherwise temporary files are removed and existing
+files are left untouched. This option can't be used along with
+--post-process.
+
+Files are downloaded concurrently, at most --threads at once. Use
+--parallel-downloads option to change number of concurrent downloads without
+affecting other operations.
+
+To keep local files which are newer than translations in project, use
+--if-newer option. Modification time of local file is compared with time of
+last modification of translation in project and file is downloaded only if
+translation was modified later or local file does not exist.
+
+To skip translations, which are not changed since source file was pushed,
+use --since-push option. Time of last modification of translation is
+compared with time when source file was last uploaded to project, so no
+local state is needed and it works the same on every machine. Translations
+which are missing locally and source files with --source are always
+downloaded.
+
 Files will be downloaded and stored under names used while upload (e.g. File
 URI). While downloading translated file suffix "_<locale>" will be appended to
 file name before extension. To override file format name, use --format option.
@@ -190,13 +407,40 @@ file name before extension. To override file format name, use --format option.
 Following variables are available:
 
   > .FileURI — full file URI in Smartling system;
-  > .Locale — locale ID for translated file and empty for source file;
+  > .Locale — locale ID for translated file and empty for source file,
+    it can be altered with --locale-map option, see below;
+
+To store translations in directory per locale, like "fr-FR/messages.json",
+use --locale-subdirectory option. It's the same as using following format:
+
+  --format '` + localeSubdirectoryPullFormat + `'
+
+Therefore it can't be used along with --format option.
+
+When some locales need paths which can't be expressed by single format, e.g.
+"pt-BR" stored as "por/messages.json", use --locale-file-map option to give
+own format for these locales, either as <locale>=<format> pair or as path to
+JSON file with locale IDs as keys and formats as values:
+
+  --locale-file-map 'pt-BR=por/{{name .FileURI}}.json'
+  --locale-file-map locale-paths.json
+
+Formats from map override --format and pull.format from config file for
+specified locales only; other locales use them as usual. Locale IDs in map
+are project locale IDs, not names from --locale-map.
 
 
 Available options:
   -p --project <project>
     Specify project to use.
 
+  -l --locale <locale>
+    Download only specified locales. Can be specified several times or
+    as comma-separated list.
+
+  --locale-filter-regexp <regexp>
+    Download only locales, which IDs match specified regular expression.
+
   --source
     Download source files along with translated files.
 
@@ -204,12 +448,17 @@ Available options:
     Download files into specified directory.
 
   --format <format>
-    Specify format for download file nmae.
+    Specify format for download file nmae. Overrides pull.format from
+    config file. Invalid format is reported before any file is downloaded.
 
   --progress <percents>
     Specify minimum of translation progress in percents.
 	By default that filter does not apply.
 
+  --exclude <mask>
+    Skip files which URIs match specified mask. Can be specified several
+    times.
+
   --retrieve <type>
     Retrieval type according to API specs:
     > pending — returns any translations, including non-published ones);
@@ -217,9 +466,78 @@ Available options:
     > pseudo — returns modified version of original text with certain
                characters transformed;
     > contextMatchingInstrumented — to use with Chrome Context Capture;
+
+  --locale-map <locale>=<name>
+    Use specified name instead of locale ID in file names, e.g.
+    --locale-map zh-TW=zh_TW. Can be specified several times. Locales can be
+    mapped in config file under "locale_map" key as well.
+
+  --locale-map-file <file>
+    Read locale names from JSON file, e.g. {"en-US": "en"}. Values from file
+    override config file and are overridden by --locale-map options.
+
+  --checksum
+    Store checksum of every downloaded file and skip unchanged files.
+
+  --skip-unchanged
+    Do not rewrite local files which contents are the same as downloaded.
+
+  --verify
+    Check local files against stored checksums without downloading them.
+
+  --checksum-file <file>
+    Store checksums in specified manifest file instead of "<file>.sha256".
+
+  --missing-only
+    Download only files which do not exist locally yet.
+
+  --overwrite-empty
+    Download only files which do not exist locally or contain no strings.
+
+  --if-newer
+    Download only translations modified after local files were written.
+
+  --since-push
+    Download only translations modified after source file was last pushed.
+
+  --on-missing-file <action>
+    Action for translations not found in project: skip (default),
+    create-empty or error.
+
+  --merge-with-source
+    Fill untranslated strings in JSON and YAML files from source file.
+
+  --locale-subdirectory
+    Store translations under directory named after locale.
+
+  --post-process <command>
+    Run shell command after every written file; {} is replaced with path.
+
+  --include-original
+    Write original file next to translations, named after source locale.
+
+  --source-locale <locale>
+    Use specified locale instead of project source locale for original files.
+
+  --atomic
+    Write downloaded files only if all downloads succeed.
+
+  --encoding <name>
+    Convert files into UTF-8 (default), UTF-16LE, UTF-16BE or Latin-1.
+
+  --bom
+    Write byte order mark into UTF-8 or UTF-16 files.
+
+  --file <path>
+    Pull translations only of specified source file. Can be specified
+    several times.
+
+  --locale-file-map <map>
+    Use own file name format for locale, as <locale>=<format> or path to
+    JSON file. Can be specified several times.
 ` + authenticationOptionsHelp
 
-const filesPushHelp = `smartling-cli files push <file> [<uri>] [--type <type>] [--branch (@auto|<branch name>)] [--authorize|--locale <locale>] [--directory <work dir>] [--directive <smartling directive>]
+const filesPushHelp = `smartling-cli files push <file> [<uri>] [--type <type>] [--branch (@auto|<branch name>)] [--authorize|--locale <locale>] [--directory <work dir>] [--directive <smartling directive>] [--dry-run]
 
 Uploads files designated for translation.
 
@@ -228,22 +546,162 @@ One or several files can be pushed.
 When pushing single file, <uri> can be specified to override local path.
 When pushing multiple files, they will be uploaded using local path as URI.
 If no file specified in command line, config file will be used to lookup
-for file masks to push.
+for file masks to push. Masks from config file are relative to the directory
+of config file, and every mask should match at least one file. Use -v option
+to see which files are matched by which mask.
 
 To authorize all locales, use --authorize option.
 
-To authorize only specific locales, use one or more --locale.
+To authorize only specific locales, use one or more --locale. Several
+locales can be specified as comma-separated list as well. Specified locales
+are checked against project target locales before any file is uploaded.
 
 To prepend prefix to all target URIs, use --branch option. Special
 value "@auto" can be used to tell that tool should try to took current git
 branch name as value for --branch option.
 
+Branch name for "@auto" is taken from first of following sources that is
+available, so it works in CI where repository is often in detached state:
+
+  > $GITHUB_HEAD_REF — GitHub Actions (pull requests);
+  > $CI_COMMIT_BRANCH — GitLab CI;
+  > $CIRCLE_BRANCH — CircleCI;
+  > $BRANCH_NAME — Jenkins multibranch pipelines;
+  > current branch of git repository containing current directory.
+
+Source which was used is logged with -v option.
+
 File type will be deduced from file extension. If file extension is unknown,
-type should be specified manually by using --type option. That option also
-can be used to override detected file type.
+type is detected from first 512 bytes of file: JSON, XML, Android XML, HTML
+and gettext PO files are recognized. Otherwise, type should be specified
+manually by using --type option. That option also can be used to override
+detected file type. Detected types are logged with -v option.
+
+To see which files will be uploaded and under which URIs without actually
+uploading anything, use --dry-run option. Files are still read and checked,
+so command will fail if any of the files can't be prepared for upload.
+
+Files are uploaded one by one. To upload several files concurrently, use
+--parallel-uploads option. Command stops starting new uploads after first
+failed upload.
 
 <file> ` + globPatternHelp + `
 
+Files can be excluded from push by using one or several --exclude options,
+which support the same patterns. Excluded files are listed with -v option.
+
+Files listed in ".smartlingignore" file, located in the same directory as
+config file, are never pushed. It uses .gitignore syntax, except that
+negated patterns ("!pattern") are not supported. Ignored files are listed
+with -v option as well.
+
+To push only some of files configured in config file, use one or several
+--file options with file path relative to directory with config file.
+Command will fail if specified file is not matched by config file patterns.
+
+To check in CI, that new strings were added, use --check-only option. Every
+file is uploaded under temporary URI, which is deleted right after check, and
+command fails if none of files contain strings unknown to project. Files in
+project are not changed.
+
+Command exits with following codes:
+
+  > 0 — files are pushed, or contain new strings with --check-only;
+  > 1 — files can't be found, prepared or pushed;
+  > 2 — API request timed out;
+  > 3 — none of files contain new strings with --check-only.
+
+By default, file is uploaded under URI, which is built from branch prefix
+and either <uri> or file path relative to config file. To use another naming
+scheme, specify --uri-format option, e.g.:
+
+  --uri-format '{{.Branch}}v2/{{.FileURI}}'
+
+Format is Golang template with the same functions as in --format option of
+pull command. Following variables are available:
+
+  > .FileURI — <uri> or file path relative to config file;
+  > .Branch — branch prefix with trailing slash or empty string;
+
+Format is checked before any file is uploaded.
+
+To upload strings into specific Smartling namespace, use --namespace option.
+Namespace can be set for files matching pattern in config file as well,
+using "namespace" key in "push" section; --namespace option takes precedence.
+Namespace used for every file is logged with -v option.
+
+To test UI with visibly translated content before real translations are
+ready, use --simulate-locale option with target locale. After every file is
+pushed, its pseudo translation into that locale is generated by Smartling
+and written to the path, which would be used by pull command, e.g.:
+
+  smartling-cli files push --simulate-locale fr-FR
+
+Pseudo translations are reported as "pseudo-translated" and are overwritten
+by real translations on next pull.
+
+To guard against uploading generated or bundled files by accident, use
+--max-file-size option with size in bytes or with KB or MB suffix, e.g. 500KB.
+Larger files are skipped with warning before anything is uploaded.
+
+To not upload stub files with only a few strings, use --min-string-count
+option. Strings are counted in local file before upload, and files with fewer
+strings are skipped; skipped files are logged with -v option. Empty strings
+are not counted. Strings can be counted only in JSON, YAML, Java properties
+and plain text files; files of other types are pushed with warning.
+
+To skip files which are not changed since last push, use --smart-update
+option. Hashes of pushed files are stored in ".smartling-hashes" file in the
+same directory as config file, keyed by file URI, and updated after every
+successful upload. Only file contents are compared, so to upload files again
+after changing directives or type, add --force option: it takes precedence
+over --smart-update, so all files are uploaded and their hashes are updated.
+
+Hashes are computed with SHA-256 by default, so hashes file can be checked
+with sha256sum tool. To use faster or shorter hashes, use --hash-algorithm
+option with sha1, md5 or xxhash (64-bit XXH64) along with --smart-update.
+Algorithm is recorded in the first line of hashes file, and hashes written
+with another algorithm are ignored, so all files are pushed once after
+algorithm is changed.
+
+To delete files from project, which source files were removed locally, use
+--delete-removed option. After all files are pushed, project files under
+current --branch prefix (or all project files without --branch), which URIs
+do not match any pushed file, are listed and deleted after confirmation.
+Use --force to delete them without confirmation, e.g. in CI, or --dry-run to
+only list them. Files are not deleted if push has failed. This option can be
+used only when all files from config file are pushed, so it can't be used
+along with <file>, --file, --exclude or --max-file-size.
+
+To push same files under several branch prefixes at once, e.g. to feature
+branch and to trunk while backporting string fix, use --branches option with
+comma-separated list of branches. Every branch is pushed concurrently and
+result is reported for every branch; @auto can be used in the list as well.
+It can't be used along with --smart-update, since hashes of pushed files are
+not tracked per branch prefix.
+
+To get machine-readable results, e.g. to update CI dashboards, use --report
+option with path to file. JSON array is written there with following fields
+for every processed file, even if push has failed:
+
+  > file — local file path;
+  > uri — file URI in project;
+  > type — file type;
+  > status — new, overwritten, not changed, skipped, dry run, deleted or failed;
+  > pushed — true if file was uploaded;
+  > deleted — true if file was deleted from project;
+  > strings — strings count in uploaded file;
+  > words — words count in uploaded file;
+  > error — error message for failed file.
+
+Files, which were not started because of failure of other file, are not
+listed. With --watch, report is written after initial push only.
+
+To push files again every time they are changed, use --watch option. After
+initial push, command will keep running and watching for changes until it's
+interrupted by Ctrl+C. Errors while pushing changed file are logged, but do
+not stop watching.
+
 
 Available options:
   -p --project <project>
@@ -253,14 +711,69 @@ Available options:
     Authorize all available locales. Incompatible with --locale option.
 
   --locale <locale>
-    Authorize speicified locale only. Can be specified several times.
-    Incompatible with --authorize option.
+    Authorize speicified locale only. Can be specified several times or
+    as comma-separated list. Incompatible with --authorize option.
+
+  --locale-list-file <file>
+    Check --locale values against JSON list of locales, e.g.
+    [{"localeId": "es"}], instead of requesting project locales from API.
 
   --branch <branch>
     Prepend specified prefix to target file URI.
 
   --type <type>
     Override automatically detected file type.
+
+  --dry-run
+    Do not upload files, only list files that will be uploaded.
+
+  --exclude <mask>
+    Skip files which paths relative to project directory match specified
+    mask. Can be specified several times.
+
+  --file <path>
+    Push only specified configured file. Can be specified several times.
+
+  --watch
+    Watch for changes in pushed files and push them again.
+
+  --check-only
+    Do not push files, only fail if they do not contain new strings.
+
+  --branches <list>
+    Push files under every branch prefix from comma-separated list.
+    Incompatible with --branch and --smart-update options.
+
+  --uri-format <format>
+    Use specified format to build file URIs in project.
+
+  --smart-update
+    Skip files which contents are not changed since last push.
+
+  --force
+    Push all files, even if they are not changed since last push. Deletes
+    files with --delete-removed without confirmation.
+
+  --hash-algorithm <name>
+    Use sha256 (default), sha1, md5 or xxhash for --smart-update hashes.
+
+  --delete-removed
+    Delete project files under branch prefix which are not found locally.
+
+  --max-file-size <size>
+    Skip files larger than specified size in bytes, KB or MB.
+
+  --min-string-count <n>
+    Skip files with fewer than specified number of non-empty strings.
+
+  --namespace <name>
+    Upload strings into specified Smartling namespace.
+
+  --simulate-locale <locale>
+    Write pseudo translation of every pushed file into specified locale.
+
+  --report <path>
+    Write results of push as JSON array into specified file.
 ` + authenticationOptionsHelp
 
 const filesStatusHelp = `smartling-cli files status — show files status from project.
@@ -289,7 +802,71 @@ To override default file name format --format can be used.
 Following variables are available:
 
   > .FileURI — full file URI in Smartling system;
-  > .Locale — locale ID for translated file and empty for source file;
+  > .Locale — locale ID for translated file and empty for source file,
+    it can be altered with --locale-map option, see below;
+
+To use status in scripts, --output option can be set to "json" or "csv".
+In that case, following fields are written for every file and locale:
+
+  > file — full file URI in Smartling system;
+  > path — local file path;
+  > locale — locale ID;
+  > state — file status on local system;
+  > awaiting_authorization — strings count awaiting authorization;
+  > in_progress — strings count authorized, but not yet translated;
+  > completed — translated strings count;
+
+To archive status snapshots, e.g. along with CI build artifacts, use --export
+option with path to file. Status of every file and locale is written into
+that file in format specified by --output option, and summary is displayed
+instead. Use --quiet option to not display summary.
+
+To show only files with recent activity, use --since option with date in
+YYYY-MM-DD format. Files, which were neither uploaded nor had translations
+modified after specified date, are omitted from output.
+
+To show status only for some locales, use --locale option, which can be
+specified several times or as comma-separated list. Source files are always
+listed.
+
+To see how translated every locale is, use --summary option. Instead of line
+per file and locale, one line per locale is displayed with strings counts
+summed over all listed files, followed by overall completion:
+
+  > Locale ID
+  > Completion Percentage
+  > Completed Strings Count
+  > Total Strings Count
+
+Summary is sorted by completion percentage, most complete locales first. Use
+--sort locale to sort it by locale ID instead.
+
+To see rollups by language family, use --locale-groups option with path to
+JSON file, which maps group names to lists of locales, e.g.:
+
+  {"spanish": ["es-ES", "es-MX", "es-AR"], "french": ["fr-FR", "fr-CA"]}
+
+Summary is displayed then with one line per group, with strings counts summed
+over all locales of the group. Locales, which are not listed in any group,
+are displayed as is.
+
+To hide noise on project nearing completion, use --only-incomplete option.
+Lines of completely translated locales are omitted, and file, including its
+source line, is omitted when all its listed locales are complete. With
+--summary, completely translated locales are omitted and overall completion
+is computed over displayed locales only. Completion used by --fail-below is
+still computed over all files and locales.
+
+To monitor translation progress, use --watch option: terminal is cleared and
+status is displayed again every 10 seconds until command is interrupted by
+Ctrl+C. Interval can be changed by --watch-interval option, which accepts
+number of seconds or duration like 1m. To stop refreshing once all locales
+are completely translated, use --watch-until-complete instead of --watch.
+
+To block release until translations are complete enough, use --fail-below
+option with percents from 0 to 100. Completion of every locale is computed
+over all listed files, and command fails listing every locale which is
+translated less than specified percents.
 
 <uri> ` + globPatternHelp + `
 
@@ -303,6 +880,97 @@ Available options:
 
   --format <format>
     Specify format for listing file names.
+
+  --output <type>
+    Output type: table (default), json or csv.
+
+  --exclude <mask>
+    Skip files which URIs match specified mask. Can be specified several
+    times.
+
+  --since <date>
+    Show only files changed after specified date.
+
+  --fail-below <percents>
+    Fail if completion of any locale is below specified percents.
+
+  -l --locale <locale>
+    Show status only for specified locales. Can be specified several times
+    or as comma-separated list.
+
+  --summary
+    Show one line per locale instead of line per file and locale.
+
+  --sort <order>
+    Sort summary by "completion" (default) or by "locale".
+
+  --only-incomplete
+    Hide completely translated files and locales.
+
+  --locale-groups <file>
+    Show summary with locales aggregated by groups from JSON file.
+
+  --export <path>
+    Write status into specified file and display summary instead.
+
+  --watch
+    Display status again every --watch-interval until interrupted.
+
+  --watch-until-complete
+    Display status again until all locales are completely translated.
+
+  --watch-interval <interval>
+    Interval between refreshes in seconds or as duration. Default is 10s.
+
+  --locale-map <locale>=<name>
+    Use specified name instead of locale ID in file names.
+
+  --locale-map-file <file>
+    Read locale names from JSON file, e.g. {"en-US": "en"}.
+` + authenticationOptionsHelp
+
+const filesCleanHelp = `smartling-cli files clean — remove local files written by pull and push.
+
+Removes files, which are written by CLI next to project files:
+
+  > "<file>.sha256" checksum files written by pull --checksum in --directory
+    and its subdirectories;
+  > ".smartling-hashes" file written by push --smart-update next to config
+    file;
+  > checksums manifest specified via --checksum-file option.
+
+Checksum files are recognized by their contents, so other files with
+".sha256" extension are left intact.
+
+To remove translations written by pull command as well, use --all-pulled
+option. Local paths are built from files and locales in project, so use the
+same <uri>, --directory and --format options as for pull command. Source
+files are never removed.
+
+Every removed file is listed along with total count of removed files. Use
+--dry-run option to list files, that will be removed, without actually
+removing them.
+
+<uri> ` + globPatternHelp + `
+
+Available options:
+  -p --project <project>
+    Specify project to use.
+
+  -d --directory <dir>
+    Look for checksum and pulled files in specified directory.
+
+  --format <format>
+    Use specified format to build paths of pulled files.
+
+  --all-pulled
+    Remove translations written by pull command.
+
+  --checksum-file <file>
+    Remove specified checksums manifest.
+
+  --dry-run
+    Do not remove files, only list them.
 ` + authenticationOptionsHelp
 
 const filesDeleteHelp = `smartling-cli files delete — removes files from project.
@@ -316,18 +984,168 @@ to read files list from stdin:
 
   cat files.txt | smartling-cli files delete -
 
+To delete files uploaded with specific --branch option, use the same --branch
+option for delete command. If <uri> is not specified, all files with that
+prefix will be deleted:
+
+  smartling-cli files delete --branch feature-x
+
+Use --dry-run option to list files, that will be deleted, without actually
+deleting them.
+
+Command will fail if no files match specified pattern and branch.
+
+Available options:
+  -p --project <project>
+    Specify project to use.
+
+  --branch <branch>
+    Delete only files with specified prefix.
+
+  --dry-run
+    Do not delete files, only list them.
+` + authenticationOptionsHelp
+
+const filesValidateHelp = `smartling-cli files validate — check setup before push.
+
+Performs following checks without uploading anything:
+
+  > credentials are valid;
+  > project exists and accessible;
+  > pull formats from config file are valid;
+  > every pattern matches at least one file;
+  > every matching file is readable and its type can be determined;
+
+Files are selected same way as for "files push" command: either by
+specified <file> pattern or by patterns from config file.
+
+Every check is reported on separate line and command fails if any of checks
+is failed, so it can be used in CI before actual push.
+
 Available options:
   -p --project <project>
     Specify project to use.
+
+  --type <type>
+    Override automatically detected file type.
+
+  --directory <dir>
+    Use specified directory as root for pattern search.
+
+  --directive <directive>
+    Check specified Smartling directive format.
+
+  --exclude <mask>
+    Skip files matching specified mask.
+` + authenticationOptionsHelp
+
+const filesDiffHelp = `smartling-cli files diff — compare local files with project.
+
+Shows how many strings were added and removed in local files since they were
+pushed last time, in a way similar to "git diff --stat".
+
+Files are selected same way as for "files push" command: either by
+specified <file> pattern or by patterns from config file.
+
+To compare local files, they are uploaded into project under temporary URI
+with "` + diffURIPrefix + `" prefix, which is deleted right after
+comparison, even if comparison has failed. Newly added strings are not
+authorized for translation. If command is interrupted by Ctrl+C, it stops
+after temporary file of current file is deleted.
+
+Added strings are counted as strings awaiting authorization in temporary file,
+which were not awaiting authorization in pushed file, so strings, which were
+not authorized before, are not counted as added.
+
+Files without changes are not listed.
+
+With --strings option, original files are downloaded from project instead and
+compared with local files key by key. Added, removed and changed strings are
+printed in unified diff style:
+
+  --- <uri>
+  +++ <file>
+  +<key>: "<value>"
+  -<key>: "<value>"
+
+Nested keys are joined with dots. Only JSON and YAML files are supported, other
+files are skipped with a warning.
+
+Available options:
+  -p --project <project>
+    Specify project to use.
+
+  -b --branch <branch>
+    Compare with files pushed with specified branch prefix.
+
+  --strings
+    Show added, removed and changed strings instead of counts.
+
+  --type <type>
+    Override automatically detected file type.
+
+  --directory <dir>
+    Use specified directory as root for pattern search.
+
+  --directive <directive>
+    Specify Smartling directive to use for temporary file.
+
+  --exclude <mask>
+    Skip files matching specified mask.
+` + authenticationOptionsHelp
+
+const filesAuthorizeHelp = `smartling-cli files authorize — authorize strings for translation.
+
+Authorizes all strings, which are awaiting authorization, in specified files,
+so translators can start working on them.
+
+If no <uri> is specified, all project files will be authorized.
+
+To authorize only specific locales, use one or more --locale options. Several
+locales can be specified as comma-separated list as well.
+
+Authorization is done by uploading original file contents back with
+authorization flag, so file-specific directives from config file are used
+as well.
+
+Authorization is processed by Smartling asynchronously, so --wait option
+can be used to wait until there are no strings awaiting authorization.
+
+<uri> ` + globPatternHelp + `
+
+
+Available options:
+  -p --project <project>
+    Specify project to use.
+
+  -l --locale <locale>
+    Authorize only specified locales.
+
+  --wait
+    Wait until all strings are authorized.
 ` + authenticationOptionsHelp
 
 const filesRenameHelp = `smartling-cli files rename — rename specified file.
 
-Renames specified file URI into new file URI.
+Renames specified file URI into new file URI. Command will fail if file
+with specified URI does not exist in project.
+
+To rename several files at once, use --prefix option. In that case, <old-uri>
+and <new-uri> are treated as URI prefixes, and every file which URI starts
+with <old-uri> will be renamed to have <new-uri> prefix instead. It's useful
+to move files between branches, for example:
+
+  smartling-cli files rename --prefix release-1/ release-2/
+
+Every file is renamed separately, so if rename of some file fails, remaining
+files are still renamed and command fails after all files are processed.
 
 Available options:
   -p --project <project>
     Specify project to use.
+
+  --prefix
+    Rename all files with specified prefix.
 ` + authenticationOptionsHelp
 
 const importHelp = `smartling-cli import — import file translations.
@@ -359,6 +1177,9 @@ func showHelp(args map[string]interface{}) {
 	case args["init"].(bool):
 		fmt.Print(initHelp)
 
+	case args["config"].(bool):
+		fmt.Print(configShowHelp)
+
 	case args["projects"].(bool):
 		switch {
 		case args["list"].(bool):
@@ -367,6 +1188,8 @@ func showHelp(args map[string]interface{}) {
 			fmt.Print(projectsInfoHelp)
 		case args["locales"].(bool):
 			fmt.Print(projectsLocalesHelp)
+		case args["stats"].(bool):
+			fmt.Print(projectsStatsHelp)
 		}
 
 	case args["files"].(bool):
@@ -381,10 +1204,18 @@ func showHelp(args map[string]interface{}) {
 			fmt.Print(filesStatusHelp)
 		case args["delete"].(bool):
 			fmt.Print(filesDeleteHelp)
+		case args["validate"].(bool):
+			fmt.Print(filesValidateHelp)
+		case args["diff"].(bool):
+			fmt.Print(filesDiffHelp)
+		case args["authorize"].(bool):
+			fmt.Print(filesAuthorizeHelp)
 		case args["rename"].(bool):
 			fmt.Print(filesRenameHelp)
 		case args["import"].(bool):
 			fmt.Print(importHelp)
+		case args["clean"].(bool):
+			fmt.Print(filesCleanHelp)
 		}
 
 	default:
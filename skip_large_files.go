@@ -0,0 +1,35 @@
+package main
+
+import (
+	"os"
+
+	"github.com/reconquest/hierr-go"
+)
+
+// skipLargeFiles leaves only files which size does not exceed limit in
+// bytes and warns about every skipped file.
+func skipLargeFiles(files []string, limit int64) ([]string, error) {
+	var result []string
+
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, hierr.Errorf(err, `unable to get size of "%s"`, file)
+		}
+
+		if info.Size() > limit {
+			logger.Warningf(
+				"skipping %s: size %d bytes exceeds --max-file-size (%d bytes)",
+				file,
+				info.Size(),
+				limit,
+			)
+
+			continue
+		}
+
+		result = append(result, file)
+	}
+
+	return result, nil
+}
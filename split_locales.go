@@ -0,0 +1,22 @@
+package main
+
+import (
+	"strings"
+)
+
+func splitLocales(values []string) []string {
+	var locales []string
+
+	for _, value := range values {
+		for _, locale := range strings.Split(value, ",") {
+			locale = strings.TrimSpace(locale)
+			if locale == "" {
+				continue
+			}
+
+			locales = append(locales, locale)
+		}
+	}
+
+	return locales
+}
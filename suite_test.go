@@ -3,12 +3,14 @@ package main
 import (
 	"bytes"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"os/exec"
 	"sort"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/Smartling/api-sdk-go"
@@ -24,6 +26,11 @@ type MainSuite struct {
 	suite.Suite
 
 	Mock struct {
+		// lock only orders accesses to test variables made by handler and
+		// by test itself, since race detector can't see synchronization
+		// through command process; handlers still run concurrently
+		sync.RWMutex
+
 		Server  *httptest.Server
 		Handler http.HandlerFunc
 	}
@@ -33,6 +40,9 @@ func (suite *MainSuite) SetupSuite() {
 	suite.Mock.Server = httptest.NewUnstartedServer(
 		http.HandlerFunc(
 			func(writer http.ResponseWriter, request *http.Request) {
+				suite.Mock.RLock()
+				defer suite.Mock.RUnlock()
+
 				auth, err := handleAuthentication(writer, request)
 				if err != nil {
 					panic(err)
@@ -58,10 +68,25 @@ func (suite *MainSuite) TearDownSuite() {
 }
 
 func (suite *MainSuite) run(opts ...interface{}) (bool, string, string) {
+	code, stdout, stderr := suite.runWithExitCode(opts...)
+
+	return code == 0, stdout, stderr
+}
+
+func (suite *MainSuite) runWithExitCode(
+	opts ...interface{},
+) (int, string, string) {
 	var (
-		success = true
-		stdout  = &bytes.Buffer{}
-		stderr  = &bytes.Buffer{}
+		code   = 0
+		stdout = &bytes.Buffer{}
+		stderr = &bytes.Buffer{}
+		env    = []string{"_TEST_RUN=1"}
+
+		stdin io.Reader
+
+		// control is called after process is started, e.g. to change
+		// watched files and to interrupt process
+		control func(*os.Process)
 	)
 
 	args := []string{
@@ -76,6 +101,17 @@ func (suite *MainSuite) run(opts ...interface{}) (bool, string, string) {
 		switch opt := opt.(type) {
 		case string:
 			args = append(args, opt)
+
+		case io.Reader:
+			stdin = opt
+
+		case func(*os.Process):
+			control = opt
+
+		case map[string]string:
+			for name, value := range opt {
+				env = append(env, name+"="+value)
+			}
 		}
 	}
 
@@ -84,19 +120,35 @@ func (suite *MainSuite) run(opts ...interface{}) (bool, string, string) {
 		args...,
 	)
 
-	cmd.Env = append(cmd.Env, "_TEST_RUN=1")
+	cmd.Env = env
+	cmd.Stdin = stdin
 	cmd.Stdout = stdout
 	cmd.Stderr = stderr
 
-	err := cmd.Run()
+	suite.Mock.Lock()
+	suite.Mock.Unlock()
+
+	err := cmd.Start()
+	if err != nil {
+		panic(err)
+	}
+
+	if control != nil {
+		go control(cmd.Process)
+	}
+
+	err = cmd.Wait()
 	if err, ok := err.(*exec.ExitError); ok {
-		success = err.Success()
+		code = err.ExitCode()
 	}
 
+	suite.Mock.Lock()
+	suite.Mock.Unlock()
+
 	stdoutString := strings.TrimSuffix(stdout.String(), "PASS\n")
 	stderrString := stderr.String()
 
-	return success, stdoutString, stderrString
+	return code, stdoutString, stderrString
 }
 
 func (suite *MainSuite) assertStdout(output []string, args ...interface{}) {
@@ -2,12 +2,14 @@ package main
 
 import (
 	"sync"
+	"time"
 )
 
 type ThreadPool struct {
 	available chan struct{}
 	size      int
 	group     sync.WaitGroup
+	tasks     int
 }
 
 func NewThreadPool(size int) *ThreadPool {
@@ -28,8 +30,22 @@ func (pool *ThreadPool) Do(task func()) {
 
 	pool.group.Add(1)
 
+	pool.tasks++
+
+	id := pool.tasks
+
 	go func() {
+		started := time.Now()
+
+		logger.Debugf("thread pool: task #%d started", id)
+
 		defer func() {
+			logger.Debugf(
+				"thread pool: task #%d finished in %s",
+				id,
+				time.Since(started),
+			)
+
 			pool.group.Done()
 			pool.available <- struct{}{}
 		}()
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// TimeoutTransport limits time of every API request, including all retries
+// of that request and reading of response body, and keeps track of requests
+// which were pending when the timeout has been reached.
+type TimeoutTransport struct {
+	http.RoundTripper
+
+	Timeout time.Duration
+
+	mutex   sync.Mutex
+	pending map[*http.Request]string
+	expired map[string]bool
+}
+
+// timeoutBody reports timeout which is reached while response body is
+// read and releases request context when body is closed.
+type timeoutBody struct {
+	io.ReadCloser
+
+	transport *TimeoutTransport
+	request   *http.Request
+	cancel    context.CancelFunc
+}
+
+func (body timeoutBody) Read(buffer []byte) (int, error) {
+	size, err := body.ReadCloser.Read(buffer)
+	if err != nil && err != io.EOF {
+		body.transport.check(body.request)
+	}
+
+	return size, err
+}
+
+func (body timeoutBody) Close() error {
+	defer body.cancel()
+
+	body.transport.done(body.request)
+
+	return body.ReadCloser.Close()
+}
+
+func (transport *TimeoutTransport) RoundTrip(
+	request *http.Request,
+) (*http.Response, error) {
+	if transport.Timeout == 0 {
+		return transport.RoundTripper.RoundTrip(request)
+	}
+
+	ctx, cancel := context.WithTimeout(
+		request.Context(),
+		transport.Timeout,
+	)
+
+	request = request.WithContext(ctx)
+
+	transport.mutex.Lock()
+	if transport.pending == nil {
+		transport.pending = map[*http.Request]string{}
+	}
+
+	transport.pending[request] = request.Method + " " + request.URL.RequestURI()
+	transport.mutex.Unlock()
+
+	response, err := transport.RoundTripper.RoundTrip(request)
+	if err != nil {
+		transport.check(request)
+		transport.done(request)
+
+		cancel()
+
+		return nil, err
+	}
+
+	// request is considered pending until its body is read, so timeout
+	// can be reached after headers are already received
+	response.Body = timeoutBody{
+		ReadCloser: response.Body,
+		transport:  transport,
+		request:    request,
+		cancel:     cancel,
+	}
+
+	return response, nil
+}
+
+// check marks all pending operations as expired if given request has
+// failed because its timeout has been reached.
+func (transport *TimeoutTransport) check(request *http.Request) {
+	if request.Context().Err() != context.DeadlineExceeded {
+		return
+	}
+
+	transport.mutex.Lock()
+	defer transport.mutex.Unlock()
+
+	if transport.expired == nil {
+		transport.expired = map[string]bool{}
+	}
+
+	for _, operation := range transport.pending {
+		transport.expired[operation] = true
+	}
+}
+
+func (transport *TimeoutTransport) done(request *http.Request) {
+	transport.mutex.Lock()
+	defer transport.mutex.Unlock()
+
+	delete(transport.pending, request)
+}
+
+// GetExpired returns list of operations which were pending at the moment
+// when any of requests has been timed out.
+func (transport *TimeoutTransport) GetExpired() []string {
+	transport.mutex.Lock()
+	defer transport.mutex.Unlock()
+
+	expired := []string{}
+
+	for operation := range transport.expired {
+		expired = append(expired, operation)
+	}
+
+	sort.Strings(expired)
+
+	return expired
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gobwas/glob"
+)
+
+// knownFileTypes lists file types which are accepted by Smartling Files API.
+var knownFileTypes = []string{
+	"android",
+	"csv",
+	"docx",
+	"gettext",
+	"html",
+	"idml",
+	"ios",
+	"javaProperties",
+	"json",
+	"plaintext",
+	"pptx",
+	"qt",
+	"resx",
+	"stringsdict",
+	"xliff",
+	"xlsx",
+	"xml",
+	"yaml",
+}
+
+// validateConfig checks file-specific sections of config file and reports
+// all found problems at once as numbered list.
+func validateConfig(config Config) error {
+	var problems []string
+
+	keys := []string{}
+	for key := range config.Files {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		section := config.Files[key]
+
+		if key != "default" {
+			_, err := glob.Compile(key, '/')
+			if err != nil {
+				problems = append(
+					problems,
+					fmt.Sprintf(`files: pattern "%s" is malformed: %s`, key, err),
+				)
+			}
+		}
+
+		if section.Push.Type != "" && !isKnownFileType(section.Push.Type) {
+			problems = append(
+				problems,
+				fmt.Sprintf(
+					`files."%s".push.type: unknown file type "%s", should be `+
+						`one of: %s`,
+					key,
+					section.Push.Type,
+					strings.Join(knownFileTypes, ", "),
+				),
+			)
+		}
+
+		if section.Pull.Format != "" {
+			_, err := compileFormat(section.Pull.Format)
+			if err != nil {
+				if err, ok := err.(Error); ok {
+					problems = append(
+						problems,
+						fmt.Sprintf(
+							`files."%s".pull.format: %s`,
+							key,
+							err.Cause,
+						),
+					)
+				}
+			}
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	for index := range problems {
+		problems[index] = fmt.Sprintf("  %d. %s", index+1, problems[index])
+	}
+
+	return NewError(
+		fmt.Errorf(
+			"config file \"%s\" is invalid:\n\n%s",
+			config.path,
+			strings.Join(problems, "\n"),
+		),
+
+		`Fix listed problems according to documentation and try again.`,
+	)
+}
+
+func isKnownFileType(fileType string) bool {
+	for _, known := range knownFileTypes {
+		if known == fileType {
+			return true
+		}
+	}
+
+	return false
+}
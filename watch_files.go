@@ -0,0 +1,80 @@
+package main
+
+import (
+	"os"
+	"os/signal"
+	"time"
+)
+
+const (
+	watchInterval = 100 * time.Millisecond
+	watchCooldown = 500 * time.Millisecond
+)
+
+// watchFiles polls specified files for changes and calls handler for every
+// changed file after it's not changed for a cooldown period, so rapid saves
+// are handled only once. Returns when SIGINT is received.
+func watchFiles(files []string, handler func(file string)) error {
+	type state struct {
+		modified time.Time
+		size     int64
+		changed  time.Time
+	}
+
+	states := map[string]*state{}
+
+	for _, file := range files {
+		states[file] = &state{}
+
+		info, err := os.Stat(file)
+		if err == nil {
+			states[file].modified = info.ModTime()
+			states[file].size = info.Size()
+		}
+	}
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-interrupt:
+			return nil
+
+		case now := <-ticker.C:
+			for _, file := range files {
+				current := states[file]
+
+				info, err := os.Stat(file)
+				if err != nil {
+					continue
+				}
+
+				if !info.ModTime().Equal(current.modified) ||
+					info.Size() != current.size {
+					current.modified = info.ModTime()
+					current.size = info.Size()
+					current.changed = now
+
+					continue
+				}
+
+				if current.changed.IsZero() {
+					continue
+				}
+
+				if now.Sub(current.changed) < watchCooldown {
+					continue
+				}
+
+				current.changed = time.Time{}
+
+				handler(file)
+			}
+		}
+	}
+}
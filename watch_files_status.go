@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"time"
+
+	"github.com/Smartling/api-sdk-go"
+)
+
+const defaultStatusWatchInterval = 10 * time.Second
+
+// watchFilesStatus clears terminal and shows files status again every
+// interval until SIGINT is received or, with --watch-until-complete, until
+// every locale is completely translated.
+func watchFilesStatus(
+	client *smartling.Client,
+	config Config,
+	args map[string]interface{},
+) error {
+	var (
+		untilComplete, _ = args["--watch-until-complete"].(bool)
+		interval, _      = args["--watch-interval"].(string)
+		failBelow, _     = args["--fail-below"].(string)
+	)
+
+	if failBelow != "" {
+		return NewError(
+			fmt.Errorf(`--fail-below can not be used along with --watch`),
+
+			`Use --watch-until-complete to wait until translations are `+
+				`complete.`,
+		)
+	}
+
+	period := defaultStatusWatchInterval
+
+	if interval != "" {
+		seconds, err := strconv.Atoi(interval)
+		if err == nil {
+			period = time.Duration(seconds) * time.Second
+		} else {
+			period, err = time.ParseDuration(interval)
+		}
+
+		if err != nil || period <= 0 {
+			return NewError(
+				fmt.Errorf(`invalid --watch-interval value: %q`, interval),
+
+				`Interval should be number of seconds or duration, e.g. 30s.`,
+			)
+		}
+	}
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+
+	for {
+		completion := map[string]*LocaleStats{}
+
+		// move cursor to top left corner and clear screen
+		fmt.Print("\033[H\033[2J")
+
+		fmt.Printf(
+			"every %s, press Ctrl+C to stop, updated at %s\n\n",
+			period,
+			time.Now().Format("15:04:05"),
+		)
+
+		err := showFilesStatus(client, config, args, completion)
+		if err != nil {
+			return err
+		}
+
+		if untilComplete && isTranslationComplete(completion) {
+			fmt.Println("\nall locales are completely translated")
+
+			return nil
+		}
+
+		select {
+		case <-interrupt:
+			return nil
+
+		case <-time.After(period):
+		}
+	}
+}
+
+func isTranslationComplete(completion map[string]*LocaleStats) bool {
+	for _, locale := range completion {
+		if locale.Completed < locale.Total {
+			return false
+		}
+	}
+
+	return true
+}
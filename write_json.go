@@ -0,0 +1,23 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/reconquest/hierr-go"
+)
+
+func writeJSON(writer io.Writer, value interface{}) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	err := encoder.Encode(value)
+	if err != nil {
+		return hierr.Errorf(
+			err,
+			"unable to write output as JSON",
+		)
+	}
+
+	return nil
+}
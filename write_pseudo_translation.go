@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/Smartling/api-sdk-go"
+)
+
+// writePseudoTranslation downloads pseudo translation of pushed file into
+// specified locale and writes it under path, which pull command would use
+// for that locale.
+func writePseudoTranslation(
+	client *smartling.Client,
+	config Config,
+	args map[string]interface{},
+	request *smartling.FileUploadRequest,
+	locale string,
+) error {
+	var (
+		directory = args["--directory"].(string)
+	)
+
+	file := smartling.File{
+		FileURI:  request.FileURI,
+		FileType: request.FileType,
+	}
+
+	path, err := executeFileFormat(
+		config,
+		file,
+		defaultFilePullFormat,
+		usePullFormat,
+		map[string]interface{}{
+			"FileURI": file.FileURI,
+			"Locale":  config.MapLocale(locale),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	path = filepath.Join(directory, path)
+
+	_, err = downloadFile(
+		client,
+		config.ProjectID,
+		file,
+		locale,
+		path,
+		smartling.RetrievalType("pseudo"),
+		false,
+		false,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("pseudo-translated %s -> %s [%s]\n", file.FileURI, path, locale)
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// writeTempFile copies reader contents into temporary file in the same
+// directory as specified path, so it can be renamed to that path
+// atomically, and returns temporary file name along with SHA-256 checksum
+// of written contents.
+func writeTempFile(path string, reader io.Reader) (string, string, error) {
+	temp, err := ioutil.TempFile(
+		filepath.Dir(path),
+		"."+filepath.Base(path)+".",
+	)
+	if err != nil {
+		return "", "", err
+	}
+
+	hash := sha256.New()
+
+	_, err = io.Copy(io.MultiWriter(temp, hash), reader)
+	if err == nil {
+		err = temp.Chmod(0644)
+	}
+
+	if closeErr := temp.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		os.Remove(temp.Name())
+
+		return "", "", err
+	}
+
+	return temp.Name(), hex.EncodeToString(hash.Sum(nil)), nil
+}
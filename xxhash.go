@@ -0,0 +1,146 @@
+package main
+
+import (
+	"encoding/binary"
+	"hash"
+	"math/bits"
+)
+
+// primes are variables, so initial state can be computed with overflow
+var (
+	xxhashPrime1 uint64 = 11400714785074694791
+	xxhashPrime2 uint64 = 14029467366897019727
+	xxhashPrime3 uint64 = 1609587929392839161
+	xxhashPrime4 uint64 = 9650029242287828579
+	xxhashPrime5 uint64 = 2870177450012600261
+)
+
+// xxhash64 is XXH64 hash with zero seed, which is much faster than
+// cryptographic hashes and is enough to detect changed files. It's
+// implemented here to not add dependency for single hash function.
+type xxhash64 struct {
+	v1, v2, v3, v4 uint64
+
+	total  uint64
+	buffer [32]byte
+	size   int
+}
+
+func newXXHash64() hash.Hash {
+	hash := &xxhash64{}
+	hash.Reset()
+
+	return hash
+}
+
+func (hash *xxhash64) Reset() {
+	hash.v1 = xxhashPrime1 + xxhashPrime2
+	hash.v2 = xxhashPrime2
+	hash.v3 = 0
+	hash.v4 = -xxhashPrime1
+	hash.total = 0
+	hash.size = 0
+}
+
+func (hash *xxhash64) Size() int {
+	return 8
+}
+
+func (hash *xxhash64) BlockSize() int {
+	return 32
+}
+
+func (hash *xxhash64) Write(data []byte) (int, error) {
+	written := len(data)
+
+	hash.total += uint64(written)
+
+	if hash.size+len(data) < 32 {
+		hash.size += copy(hash.buffer[hash.size:], data)
+
+		return written, nil
+	}
+
+	if hash.size > 0 {
+		count := copy(hash.buffer[hash.size:], data)
+		data = data[count:]
+
+		hash.stripe(hash.buffer[:])
+		hash.size = 0
+	}
+
+	for ; len(data) >= 32; data = data[32:] {
+		hash.stripe(data)
+	}
+
+	hash.size = copy(hash.buffer[:], data)
+
+	return written, nil
+}
+
+func (hash *xxhash64) stripe(data []byte) {
+	hash.v1 = xxhashRound(hash.v1, binary.LittleEndian.Uint64(data[0:8]))
+	hash.v2 = xxhashRound(hash.v2, binary.LittleEndian.Uint64(data[8:16]))
+	hash.v3 = xxhashRound(hash.v3, binary.LittleEndian.Uint64(data[16:24]))
+	hash.v4 = xxhashRound(hash.v4, binary.LittleEndian.Uint64(data[24:32]))
+}
+
+func (hash *xxhash64) Sum64() uint64 {
+	var sum uint64
+
+	if hash.total >= 32 {
+		sum = bits.RotateLeft64(hash.v1, 1) +
+			bits.RotateLeft64(hash.v2, 7) +
+			bits.RotateLeft64(hash.v3, 12) +
+			bits.RotateLeft64(hash.v4, 18)
+
+		for _, value := range []uint64{hash.v1, hash.v2, hash.v3, hash.v4} {
+			sum ^= xxhashRound(0, value)
+			sum = sum*xxhashPrime1 + xxhashPrime4
+		}
+	} else {
+		sum = xxhashPrime5
+	}
+
+	sum += hash.total
+
+	tail := hash.buffer[:hash.size]
+
+	for ; len(tail) >= 8; tail = tail[8:] {
+		sum ^= xxhashRound(0, binary.LittleEndian.Uint64(tail))
+		sum = bits.RotateLeft64(sum, 27)*xxhashPrime1 + xxhashPrime4
+	}
+
+	if len(tail) >= 4 {
+		sum ^= uint64(binary.LittleEndian.Uint32(tail)) * xxhashPrime1
+		sum = bits.RotateLeft64(sum, 23)*xxhashPrime2 + xxhashPrime3
+		tail = tail[4:]
+	}
+
+	for _, char := range tail {
+		sum ^= uint64(char) * xxhashPrime5
+		sum = bits.RotateLeft64(sum, 11) * xxhashPrime1
+	}
+
+	sum ^= sum >> 33
+	sum *= xxhashPrime2
+	sum ^= sum >> 29
+	sum *= xxhashPrime3
+	sum ^= sum >> 32
+
+	return sum
+}
+
+func (hash *xxhash64) Sum(data []byte) []byte {
+	sum := make([]byte, 8)
+	binary.BigEndian.PutUint64(sum, hash.Sum64())
+
+	return append(data, sum...)
+}
+
+func xxhashRound(acc uint64, input uint64) uint64 {
+	acc += input * xxhashPrime2
+	acc = bits.RotateLeft64(acc, 31)
+
+	return acc * xxhashPrime1
+}